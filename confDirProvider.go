@@ -0,0 +1,383 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	ConfDirProviderName = `ConfDirProvider`
+	ConfDirProviderTag  = `file_conf`
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	ErrNoConfFiles       = errors.New("no configuration files")
+)
+
+type ConfDirProviderOption func(*ConfDirProvider)
+
+// WithRescan makes Reload expand the pattern again, so added and removed files are taken into account.
+func WithRescan() ConfDirProviderOption {
+	return func(cp *ConfDirProvider) {
+		cp.rescan = true
+	}
+}
+
+// WithOptionalConfDir allows the directory to be missing or to have no supported files
+// (by default it's ErrNoConfFiles), then fields are set by the next providers.
+func WithOptionalConfDir() ConfDirProviderOption {
+	return func(cp *ConfDirProvider) {
+		cp.optional = true
+	}
+}
+
+// WithConfDirArrayMergePolicy sets how arrays of different files are merged.
+func WithConfDirArrayMergePolicy(policy ArrayMergePolicy) ConfDirProviderOption {
+	return func(cp *ConfDirProvider) {
+		cp.arrayPolicy = policy
+	}
+}
+
+// NewConfDirProvider creates new provider which reads values from all files matching the glob pattern
+// (`/etc/myapp/conf.d/*.json`) or from all files inside the directory (`/etc/myapp/conf.d`).
+// Files are loaded in lexical order and deep-merged, later files override earlier ones.
+// The format is chosen by the extension: .json, .jsonc, .json5, .hcl, .properties or .ini,
+// files with other extensions are skipped. Values are addressed by `file_conf:"db.host"` tag.
+// Include directives of JSON files are resolved as by WithIncludes of the JSON file provider.
+// It's an error if there are no files to load, see WithOptionalConfDir.
+func NewConfDirProvider(pattern string, opts ...ConfDirProviderOption) *ConfDirProvider {
+	cp := &ConfDirProvider{pattern: pattern}
+
+	for _, f := range opts {
+		f(cp)
+	}
+
+	return cp
+}
+
+type ConfDirProvider struct {
+	pattern     string
+	rescan      bool
+	optional    bool
+	arrayPolicy ArrayMergePolicy
+	mu          sync.RWMutex
+	fileNames   []string
+	fileData    any
+	sources     map[string]string
+}
+
+func (*ConfDirProvider) Name() string {
+	return ConfDirProviderName
+}
+
+func (*ConfDirProvider) Tag() string {
+	return ConfDirProviderTag
+}
+
+func (cp *ConfDirProvider) Init(_ any) error {
+	if _, err := cp.reload(true); err != nil {
+		return fmt.Errorf("%s.Init: %w", ConfDirProviderName, err)
+	}
+
+	return nil
+}
+
+// Reload reads the files again (and finds them again if WithRescan is set)
+// and reports whether values have been changed.
+func (cp *ConfDirProvider) Reload(_ context.Context) (bool, error) {
+	changed, err := cp.reload(cp.rescan)
+	if err != nil {
+		return false, fmt.Errorf("%s.Reload: %w", ConfDirProviderName, err)
+	}
+
+	return changed, nil
+}
+
+func (cp *ConfDirProvider) reload(rescan bool) (bool, error) {
+	cp.mu.RLock()
+	fileNames := cp.fileNames
+	cp.mu.RUnlock()
+
+	if rescan {
+		var err error
+		if fileNames, err = cp.scan(); err != nil {
+			return false, err
+		}
+	}
+
+	fileData, sources, err := cp.load(fileNames)
+	if err != nil {
+		return false, err
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	changed := !slices.Equal(cp.fileNames, fileNames) || !reflect.DeepEqual(cp.fileData, fileData)
+	cp.fileNames, cp.fileData, cp.sources = fileNames, fileData, sources
+
+	return changed, nil
+}
+
+func (cp *ConfDirProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	path := field.Tag.Get(ConfDirProviderTag)
+	if len(path) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", ConfDirProviderName)
+	}
+
+	cp.mu.RLock()
+	valStr, ok := findValStrByPath(cp.fileData, strings.Split(path, "."))
+	cp.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%s: findValStrByPath returns empty value", ConfDirProviderName)
+	}
+
+	return SetField(field, v, valStr)
+}
+
+// Files returns loaded files in order of merging.
+func (cp *ConfDirProvider) Files() []string {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.fileNames
+}
+
+// Source returns the name of the file which the value at path (e.g. `db.host`) came from.
+func (cp *ConfDirProvider) Source(path string) (string, bool) {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	fileName, ok := cp.sources[strings.ToLower(path)]
+
+	return fileName, ok
+}
+
+func (cp *ConfDirProvider) scan() ([]string, error) {
+	pattern := cp.pattern
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		pattern = filepath.Join(pattern, "*")
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+	sort.Strings(matches)
+
+	fileNames := make([]string, 0, len(matches))
+	for _, fileName := range matches {
+		info, err := os.Stat(fileName)
+		if err != nil {
+			return nil, err // nolint:wrapcheck
+		}
+
+		if info.IsDir() || documentDecoder(fileName) == nil {
+			continue
+		}
+		fileNames = append(fileNames, fileName)
+	}
+
+	if len(fileNames) == 0 && !cp.optional {
+		return nil, fmt.Errorf("%w: %s", ErrNoConfFiles, cp.pattern)
+	}
+
+	return fileNames, nil
+}
+
+// load reads and merges the files, it returns the document and files which keys came from.
+func (cp *ConfDirProvider) load(fileNames []string) (any, map[string]string, error) {
+	var (
+		fileData any
+		sources  = map[string]string{}
+	)
+
+	for _, fileName := range fileNames {
+		b, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, nil, err // nolint:wrapcheck
+		}
+
+		doc, err := decodeDocument(fileName, b)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", fileName, err)
+		}
+
+		fileData = mergeDocuments(fileData, doc, cp.arrayPolicy, fileName, "", sources)
+	}
+
+	return fileData, sources, nil
+}
+
+// decodeDocument decodes the file into a tree of map[string]any, []any and scalars choosing the format by extension.
+// Include directives of JSON files are resolved relative to the file.
+func decodeDocument(fileName string, b []byte) (any, error) {
+	decode := documentDecoder(fileName)
+	if decode == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Ext(fileName))
+	}
+
+	doc, err := decode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	unmarshal := jsonUnmarshaler(fileName)
+	if unmarshal == nil {
+		return doc, nil
+	}
+
+	root := resolveOSInclude("", fileName)
+	resolver := &includeResolver{
+		readFile:  os.ReadFile,
+		resolve:   resolveOSInclude,
+		unmarshal: unmarshal,
+		maxDepth:  defaultMaxIncludeDepth,
+		stack:     []string{root},
+	}
+
+	return resolver.resolveIncludes(doc, root)
+}
+
+// jsonUnmarshaler returns the unmarshal function of JSON files or nil for other formats.
+func jsonUnmarshaler(fileName string) func([]byte, any) error {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return json.Unmarshal
+	case ".jsonc", ".json5":
+		return unmarshalRelaxedJSON
+	}
+
+	return nil
+}
+
+func documentDecoder(fileName string) func([]byte) (any, error) {
+	if unmarshal := jsonUnmarshaler(fileName); unmarshal != nil {
+		return func(b []byte) (doc any, err error) {
+			return doc, unmarshal(b, &doc)
+		}
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".hcl":
+		return func(b []byte) (any, error) {
+			root, err := parseHCL(b)
+			if err != nil {
+				return nil, err
+			}
+
+			return hclToDocument(root), nil
+		}
+
+	case ".properties":
+		return func(b []byte) (any, error) {
+			values, err := parseProperties(bytes.NewReader(b))
+			if err != nil {
+				return nil, err
+			}
+
+			return nestKeys(values, func(val string) any { return val })
+		}
+
+	case ".ini":
+		return func(b []byte) (any, error) {
+			values, err := parseINI(bytes.NewReader(b), false)
+			if err != nil {
+				return nil, err
+			}
+
+			return nestKeys(values, func(vals []string) any {
+				if len(vals) == 1 {
+					return vals[0]
+				}
+
+				items := make([]any, 0, len(vals))
+				for _, val := range vals {
+					items = append(items, val)
+				}
+
+				return items
+			})
+		}
+	}
+
+	return nil
+}
+
+func hclToDocument(v *hclValue) any {
+	switch v.kind {
+	case hclScalar:
+		return v.str
+
+	case hclList:
+		items := make([]any, 0, len(v.list))
+		for _, item := range v.list {
+			items = append(items, hclToDocument(item))
+		}
+
+		return items
+
+	case hclObject:
+		obj := make(map[string]any, len(v.obj))
+		for key, child := range v.obj {
+			obj[key] = hclToDocument(child)
+		}
+
+		return obj
+
+	case hclNull:
+	}
+
+	return nil
+}
+
+// nestKeys converts dotted keys (`db.host`) into nested objects.
+func nestKeys[T any](values map[string]T, convert func(T) any) (any, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	doc := map[string]any{}
+	for _, key := range keys {
+		var (
+			parts = strings.Split(key, ".")
+			obj   = doc
+		)
+
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := obj[part].(map[string]any)
+			if !ok {
+				if _, exists := obj[part]; exists {
+					return nil, fmt.Errorf("%w: key [%s] conflicts with value of [%s]", ErrSyntax, key, part)
+				}
+
+				next = map[string]any{}
+				obj[part] = next
+			}
+			obj = next
+		}
+
+		last := parts[len(parts)-1]
+		if _, exists := obj[last]; exists {
+			return nil, fmt.Errorf("%w: value of [%s] conflicts with nested keys", ErrSyntax, key)
+		}
+		obj[last] = convert(values[key])
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,219 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type _confDirCfg struct {
+	Name     string        `file_conf:"name"`
+	Host     string        `file_conf:"db.host"`
+	Port     int           `file_conf:"db.port"`
+	User     string        `file_conf:"db.user"`
+	Peers    []string      `file_conf:"peers"`
+	CacheTTL time.Duration `file_conf:"cache.ttl"`
+	Size     int           `file_conf:"cache.size"`
+}
+
+func TestConfDirProvider(t *testing.T) {
+	t.Parallel()
+
+	p := NewConfDirProvider("./testdata/conf.d")
+
+	got, err := New[_confDirCfg](p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &_confDirCfg{
+		Name:     "local",
+		Host:     "db.internal",
+		Port:     5432,
+		User:     "admin",
+		Peers:    []string{"b"},
+		CacheTTL: 10 * time.Second,
+		Size:     128,
+	}, got)
+
+	assert(t, 5, len(p.Files()))
+	source, _ := p.Source("db.host")
+	assert(t, filepath.Join("testdata", "conf.d", "10-db.hcl"), source)
+}
+
+func TestConfDirProvider_Glob(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Name  string   `file_conf:"name"`
+		Host  string   `file_conf:"db.host"`
+		Peers []string `file_conf:"peers"`
+	}
+
+	got, err := New[cfg](NewConfDirProvider("./testdata/conf.d/*.json*", WithConfDirArrayMergePolicy(ArrayAppend)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &cfg{Name: "base", Host: "localhost", Peers: []string{"a"}}, got)
+}
+
+func TestConfDirProvider_Reload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "a.json"), `{"name": "a"}`, 0o600)
+
+	type cfg struct {
+		Name string `file_conf:"name"`
+	}
+
+	p := NewConfDirProvider(dir, WithRescan())
+	if _, err := New[cfg](p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changed, err := p.Reload(context.Background())
+	assert(t, nil, err)
+	assert(t, false, changed)
+
+	writeTestFile(t, filepath.Join(dir, "b.json"), `{"name": "b"}`, 0o600)
+	changed, err = p.Reload(context.Background())
+	assert(t, nil, err)
+	assert(t, true, changed)
+
+	got, err := New[cfg](p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "b", got.Name)
+	assert(t, []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}, p.Files())
+
+	writeTestFile(t, filepath.Join(dir, "c.json"), `{`, 0o600)
+	_, err = p.Reload(context.Background())
+	assert(t, true, err != nil)
+}
+
+func TestConfDirProvider_Includes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "shared", "db.json"), `{"db": {"host": "db.internal", "port": 5432}}`, 0o600)
+	writeTestFile(t, filepath.Join(dir, "conf.d", "10-app.jsonc"), `{
+		// shared fragments are kept outside of the directory, so they aren't loaded on their own
+		"$include": "../shared/db.json",
+		"db": {"port": 5433},
+	}`, 0o600)
+
+	type cfg struct {
+		Host string `file_conf:"db.host"`
+		Port int    `file_conf:"db.port"`
+	}
+
+	got, err := New[cfg](NewConfDirProvider(filepath.Join(dir, "conf.d")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db.internal", Port: 5433}, got)
+
+	writeTestFile(t, filepath.Join(dir, "shared", "db.json"), `{"$include": "../conf.d/10-app.jsonc"}`, 0o600)
+
+	err = NewConfDirProvider(filepath.Join(dir, "conf.d")).Init(nil)
+	assert(t, true, errors.Is(err, ErrIncludeCycle))
+}
+
+func TestConfDirProvider_ReloadWithProvide(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "a.json"), `{"db": {"host": "db.internal"}}`, 0o600)
+
+	type cfg struct {
+		Host string `file_conf:"db.host"`
+	}
+
+	p := NewConfDirProvider(dir)
+	if _, err := New[cfg](p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = Watch(ctx, p, time.Millisecond, func() {}, func(err error) { t.Errorf("unexpected error: %v", err) })
+	}()
+
+	// Provide is called by the configurator while the provider is being reloaded (run with -race)
+	field, _ := reflect.TypeOf(cfg{}).FieldByName("Host")
+	for range 100 {
+		var host string
+		if err := p.Provide(field, reflect.ValueOf(&host).Elem()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assert(t, "db.internal", host)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestConfDirProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "a.properties"), "db=x\ndb.host=y", 0o600)
+
+	err := NewConfDirProvider(dir).Init(nil)
+	assert(t, "ConfDirProvider.Init: "+filepath.Join(dir, "a.properties")+
+		": syntax error: key [db.host] conflicts with value of [db]", err.Error())
+
+	err = NewConfDirProvider("[").Init(nil)
+	assert(t, "ConfDirProvider.Init: syntax error in pattern", err.Error())
+
+	_, err = decodeDocument("config.yaml", nil)
+	assert(t, true, errors.Is(err, ErrUnsupportedFormat))
+}
+
+func TestNestKeys(t *testing.T) {
+	t.Parallel()
+
+	doc, err := nestKeys(map[string][]string{"a.b": {"1"}, "a.c": {"2", "3"}, "d": {"4"}}, func(vals []string) any {
+		return vals[len(vals)-1]
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, map[string]any{"a": map[string]any{"b": "1", "c": "3"}, "d": "4"}, doc)
+
+	_, err = nestKeys(map[string]string{"a.b": "1", "a.b.c": "2"}, func(val string) any { return val })
+	assert(t, "syntax error: key [a.b.c] conflicts with value of [b]", err.Error())
+}
+
+func TestConfDirProvider_EmptyDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "empty.d", "README.md"), "not a config", 0o600)
+
+	for _, pattern := range []string{
+		filepath.Join(dir, "missing.d"),
+		filepath.Join(dir, "empty.d"),
+		filepath.Join(dir, "empty.d", "*.json"),
+	} {
+		err := NewConfDirProvider(pattern).Init(nil)
+		assert(t, true, errors.Is(err, ErrNoConfFiles))
+		assert(t, "ConfDirProvider.Init: no configuration files: "+pattern, err.Error())
+
+		p := NewConfDirProvider(pattern, WithOptionalConfDir())
+		if err := p.Init(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assert(t, 0, len(p.Files()))
+	}
+}
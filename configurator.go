@@ -2,34 +2,63 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
 
-// New creates a new instance of the Configurator.
+// New creates a new instance of the Configurator and fills up the struct.
 func New[T any](
 	providers ...Provider, // providers will be executed in order of their declaration
 ) (*T, error) {
-	cfg := &Configurator[T]{
-		configPtr:           new(T),
-		providers:           providers,
-		registeredProviders: map[string]struct{}{},
-		registeredTags:      map[string]struct{}{},
-	}
+	return NewConfigurator[T](providers...).InitValues()
+}
 
-	return cfg.initValues()
+// NewConfigurator creates a new instance of the Configurator. Options may be set before calling InitValues.
+func NewConfigurator[T any](
+	providers ...Provider, // providers will be executed in order of their declaration
+) *Configurator[T] {
+	return &Configurator[T]{
+		configPtr: new(T),
+		providers: providers,
+	}
 }
 
 type Configurator[T any] struct {
+	configuratorOptions
 	configPtr           *T
 	providers           []Provider
 	registeredTags      map[string]struct{}
 	registeredProviders map[string]struct{}
+	resolved            map[string]string
+	direct              map[string]reflect.Value
+	resolving           []string
+	profile             string
+}
+
+type configuratorOptions struct {
+	interpolate bool
+	profile     string
+	profileEnv  string
+	decrypter   Decrypter
+}
+
+type ConfiguratorOption func(*configuratorOptions)
+
+// SetOptions sets options of the Configurator.
+func (c *Configurator[T]) SetOptions(opts ...ConfiguratorOption) *Configurator[T] {
+	for _, f := range opts {
+		f(&c.configuratorOptions)
+	}
+
+	return c
 }
 
 // InitValues sets values into struct field using given set of providers
-// respecting their order: first defined -> first executed
-func (c *Configurator[T]) initValues() (*T, error) {
+// respecting their order: first defined -> first executed.
+// It may be called again when sources have been changed: providers are initialized on every call
+// and the same struct is filled up (the flag provider can't be initialized twice though).
+func (c *Configurator[T]) InitValues() (*T, error) {
 	if reflect.TypeOf(c.configPtr).Elem().Kind() != reflect.Struct {
 		return nil, ErrNotAStruct
 	}
@@ -38,6 +67,11 @@ func (c *Configurator[T]) initValues() (*T, error) {
 		return nil, ErrNoProviders
 	}
 
+	// InitValues may be called again (e.g. after reloading providers), so the state of the previous call is reset
+	c.registeredProviders = map[string]struct{}{}
+	c.registeredTags = map[string]struct{}{}
+	c.resolving = nil
+
 	for _, p := range c.providers {
 		if _, ok := c.registeredProviders[p.Name()]; ok {
 			return nil, ErrProviderNameCollision
@@ -48,20 +82,36 @@ func (c *Configurator[T]) initValues() (*T, error) {
 			return nil, ErrProviderTagCollision
 		}
 		c.registeredTags[p.Tag()] = struct{}{}
+	}
+
+	c.profile = c.activeProfile()
+
+	for _, p := range c.providers {
+		if ta, ok := p.(tagsAware); ok {
+			ta.setRegisteredTags(c.registeredTags)
+		}
+
+		if pa, ok := p.(profileAware); ok {
+			// the profile is set even if it's empty, so the file of the previous profile isn't loaded again
+			pa.setProfile(c.profile)
+		}
 
 		if err := p.Init(c.configPtr); err != nil {
 			return nil, fmt.Errorf("cannot init [%s] provider: %w", p.Name(), err)
 		}
 	}
 
-	if err := c.fillUp(c.configPtr); err != nil {
+	c.resolved = map[string]string{}
+	c.direct = map[string]reflect.Value{}
+
+	if err := c.fillUp(c.configPtr, ""); err != nil {
 		return nil, err
 	}
 
 	return c.configPtr, nil
 }
 
-func (c *Configurator[T]) fillUp(i any) error {
+func (c *Configurator[T]) fillUp(i any, path string) error {
 	var (
 		t = reflect.TypeOf(i)
 		v = reflect.ValueOf(i)
@@ -74,12 +124,13 @@ func (c *Configurator[T]) fillUp(i any) error {
 
 	for i := range t.NumField() {
 		var (
-			tField = t.Field(i)
-			vField = v.Field(i)
+			tField    = t.Field(i)
+			vField    = v.Field(i)
+			fieldPath = joinPath(path, tField.Name)
 		)
 
 		if tField.Type.Kind() == reflect.Struct {
-			if err := c.fillUp(vField.Addr().Interface()); err != nil {
+			if err := c.fillUp(vField.Addr().Interface(), fieldPath); err != nil {
 				return err
 			}
 			continue
@@ -87,13 +138,13 @@ func (c *Configurator[T]) fillUp(i any) error {
 
 		if tField.Type.Kind() == reflect.Ptr && tField.Type.Elem().Kind() == reflect.Struct {
 			vField.Set(reflect.New(tField.Type.Elem()))
-			if err := c.fillUp(vField.Interface()); err != nil {
+			if err := c.fillUp(vField.Interface(), fieldPath); err != nil {
 				return err
 			}
 			continue
 		}
 
-		if err := c.applyProviders(tField, vField); err != nil {
+		if err := c.applyProviders(tField, vField, fieldPath); err != nil {
 			return err
 		}
 	}
@@ -101,10 +152,15 @@ func (c *Configurator[T]) fillUp(i any) error {
 	return nil
 }
 
-func (c *Configurator[T]) applyProviders(field reflect.StructField, v reflect.Value) error {
+func (c *Configurator[T]) applyProviders(field reflect.StructField, v reflect.Value, path string) error {
 	if !field.IsExported() {
 		return nil
 	}
+	field.Tag = profileTag(field.Tag, c.profile, c.registeredTags)
+
+	if (c.interpolate || c.decrypter != nil) && canInterpolate(field.Type) {
+		return c.applyResolved(field, v, path)
+	}
 
 	for _, provider := range c.providers {
 		if _, found := fetchTagKey(field.Tag, c.registeredTags)[provider.Tag()]; !found {
@@ -112,15 +168,20 @@ func (c *Configurator[T]) applyProviders(field reflect.StructField, v reflect.Va
 			continue
 		}
 
-		if err := provider.Provide(field, v); err == nil {
+		err := provider.Provide(field, v)
+		if err == nil {
 			return nil
 		}
+
+		if errors.Is(err, ErrProviderFailed) {
+			return fmt.Errorf("field [%s]: %w", field.Name, err)
+		}
 	}
 
 	return fmt.Errorf("field [%s] with tags [%s] hasn't been set", field.Name, field.Tag)
 }
 
-// FromEnvAndDefault is a shortcut for `New(cfg, NewEnvProvider(), NewDefaultProvider()).InitValues()`.
+// FromEnvAndDefault is a shortcut for `New[T](NewEnvProvider(), NewDefaultProvider())`.
 func FromEnvAndDefault[T any]() (*T, error) {
 	return New[T](NewEnvProvider(), NewDefaultProvider())
 }
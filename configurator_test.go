@@ -156,6 +156,50 @@ func TestProviderName(t *testing.T) {
 			provider:     NewJSONFileProvider(""),
 			expectedName: JSONFileProviderName,
 		},
+		INIFileProviderName: {
+			provider:     NewINIFileProvider(""),
+			expectedName: INIFileProviderName,
+		},
+		PropertiesFileProviderName: {
+			provider:     NewPropertiesFileProvider(""),
+			expectedName: PropertiesFileProviderName,
+		},
+		HCLFileProviderName: {
+			provider:     NewHCLFileProvider(""),
+			expectedName: HCLFileProviderName,
+		},
+		DirectoryProviderName: {
+			provider:     NewDirectoryProvider(""),
+			expectedName: DirectoryProviderName,
+		},
+		ConfDirProviderName: {
+			provider:     NewConfDirProvider(""),
+			expectedName: ConfDirProviderName,
+		},
+		HTTPProviderName: {
+			provider:     NewHTTPProvider(""),
+			expectedName: HTTPProviderName,
+		},
+		ConsulProviderName: {
+			provider:     NewConsulProvider("", ""),
+			expectedName: ConsulProviderName,
+		},
+		VaultProviderName: {
+			provider:     NewVaultProvider(""),
+			expectedName: VaultProviderName,
+		},
+		SQLProviderName: {
+			provider:     NewSQLProvider(nil, ""),
+			expectedName: SQLProviderName,
+		},
+		ExecProviderName: {
+			provider:     NewExecProvider(nil),
+			expectedName: ExecProviderName,
+		},
+		MapProviderName: {
+			provider:     NewMapProvider("env", nil),
+			expectedName: "MapProvider[env]",
+		},
 	}
 
 	for name, test := range testCases {
@@ -176,6 +220,33 @@ func TestConfigurator_NameCollision(t *testing.T) {
 	assert(t, ErrProviderNameCollision, err)
 }
 
+// nolint:paralleltest
+func TestConfigurator_InitValuesTwice(t *testing.T) {
+	t.Setenv("CONFIGURATOR_HOST", "db.internal")
+
+	type cfg struct {
+		Host string `env:"CONFIGURATOR_HOST"`
+		URL  string `default:"http://${.Host}"`
+	}
+
+	c := NewConfigurator[cfg](NewEnvProvider(), NewDefaultProvider()).SetOptions(WithInterpolation())
+
+	first, err := c.InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db.internal", URL: "http://db.internal"}, first)
+
+	t.Setenv("CONFIGURATOR_HOST", "db2.internal")
+
+	second, err := c.InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db2.internal", URL: "http://db2.internal"}, second)
+	assert(t, true, first == second)
+}
+
 func TestConfigurator_FailedProvider(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,244 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ConsulProviderName = `ConsulProvider`
+	ConsulProviderTag  = `kv`
+
+	defaultConsulWait       = 5 * time.Minute
+	defaultConsulRetryDelay = time.Second
+)
+
+type ConsulProviderOption func(*ConsulProvider)
+
+// WithConsulToken sets the ACL token which is sent in `X-Consul-Token` header.
+func WithConsulToken(token string) ConsulProviderOption {
+	return func(cp *ConsulProvider) {
+		cp.token = token
+	}
+}
+
+// WithConsulHTTPClient sets the client which is used instead of http.DefaultClient.
+func WithConsulHTTPClient(client *http.Client) ConsulProviderOption {
+	return func(cp *ConsulProvider) {
+		cp.client = client
+	}
+}
+
+// WithConsulTimeout sets the timeout of the request in Init (10s by default).
+func WithConsulTimeout(timeout time.Duration) ConsulProviderOption {
+	return func(cp *ConsulProvider) {
+		cp.timeout = timeout
+	}
+}
+
+// WithConsulWaitTime sets the maximum duration of a blocking query (5m by default).
+func WithConsulWaitTime(wait time.Duration) ConsulProviderOption {
+	return func(cp *ConsulProvider) {
+		cp.wait = wait
+	}
+}
+
+// NewConsulProvider creates new provider which reads all keys under the prefix from a Consul-compatible KV API
+// (`http://127.0.0.1:8500`) during Init. Keys are addressed relative to the prefix: `kv:"db/host"`.
+func NewConsulProvider(addr, prefix string, opts ...ConsulProviderOption) *ConsulProvider {
+	cp := &ConsulProvider{
+		addr:    strings.TrimRight(addr, "/"),
+		prefix:  strings.Trim(prefix, "/"),
+		client:  http.DefaultClient,
+		timeout: defaultTimeout,
+		wait:    defaultConsulWait,
+	}
+
+	for _, f := range opts {
+		f(cp)
+	}
+
+	return cp
+}
+
+type ConsulProvider struct {
+	addr    string
+	prefix  string
+	token   string
+	client  *http.Client
+	timeout time.Duration
+	wait    time.Duration
+	mu      sync.RWMutex
+	values  map[string]string
+	index   uint64
+}
+
+type consulPair struct {
+	Key   string
+	Value []byte
+}
+
+func (*ConsulProvider) Name() string {
+	return ConsulProviderName
+}
+
+func (*ConsulProvider) Tag() string {
+	return ConsulProviderTag
+}
+
+func (cp *ConsulProvider) Init(_ any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cp.timeout)
+	defer cancel()
+
+	values, index, err := cp.load(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w", ConsulProviderName, err)
+	}
+	cp.mu.Lock()
+	cp.values, cp.index = values, index
+	cp.mu.Unlock()
+
+	return nil
+}
+
+func (cp *ConsulProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	key := field.Tag.Get(ConsulProviderTag)
+	if len(key) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", ConsulProviderName)
+	}
+
+	cp.mu.RLock()
+	val, ok := cp.values[cp.fullKey(key)]
+	cp.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%s: %w", ConsulProviderName, ErrEmptyValue)
+	}
+
+	return SetField(field, v, val)
+}
+
+// WaitForChange blocks until keys under the prefix are changed (using blocking queries) and reloads them.
+// New values are available for the next Provide calls. It returns the error of ctx if it's done first.
+// Queries which return without changes are repeated after a delay, so servers which don't block aren't flooded.
+func (cp *ConsulProvider) WaitForChange(ctx context.Context) error {
+	for {
+		changed, err := cp.Reload(ctx)
+		if err != nil {
+			return fmt.Errorf("%s.WaitForChange: %w", ConsulProviderName, err)
+		}
+
+		if changed {
+			return nil
+		}
+
+		if err := sleepCtx(ctx, defaultConsulRetryDelay); err != nil {
+			return fmt.Errorf("%s.WaitForChange: %w", ConsulProviderName, err)
+		}
+	}
+}
+
+// Reload waits until keys under the prefix are changed using the blocking query (see WithConsulWaitTime)
+// and reads them again. It reports no changes if the wait time is over, so it's meant to be called
+// by WaitForChange or by Watch which interval limits the rate of requests to servers which don't block.
+func (cp *ConsulProvider) Reload(ctx context.Context) (bool, error) {
+	cp.mu.RLock()
+	index := cp.index
+	cp.mu.RUnlock()
+
+	values, newIndex, err := cp.load(ctx, index)
+	if err != nil {
+		return false, fmt.Errorf("%s.Reload: %w", ConsulProviderName, err)
+	}
+
+	if newIndex == index {
+		return false, nil // the wait time is over without changes
+	}
+
+	if newIndex < index {
+		newIndex = 0 // the index was reset, the next query must not block
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	changed := !maps.Equal(cp.values, values)
+	cp.values, cp.index = values, newIndex
+
+	return changed, nil
+}
+
+func (cp *ConsulProvider) fullKey(key string) string {
+	if len(cp.prefix) == 0 {
+		return key
+	}
+
+	return cp.prefix + "/" + strings.TrimPrefix(key, "/")
+}
+
+// load reads all keys under the prefix, the request blocks while the index of the data equals to index.
+func (cp *ConsulProvider) load(ctx context.Context, index uint64) (map[string]string, uint64, error) {
+	query := url.Values{"recurse": {"true"}}
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", fmt.Sprintf("%ds", int(cp.wait.Seconds())))
+	}
+
+	keyPath := ""
+	if len(cp.prefix) > 0 {
+		keyPath = cp.prefix + "/"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cp.addr+"/v1/kv/"+keyPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, 0, err // nolint:wrapcheck
+	}
+
+	if len(cp.token) > 0 {
+		req.Header.Set("X-Consul-Token", cp.token)
+	}
+
+	resp, err := cp.client.Do(req)
+	if err != nil {
+		return nil, 0, err // nolint:wrapcheck
+	}
+	defer resp.Body.Close()
+
+	// the index must be greater than zero, otherwise the next query doesn't block
+	newIndex, _ := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	newIndex = max(newIndex, 1)
+	values := map[string]string{}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound: // there are no keys under the prefix
+		return values, newIndex, nil
+
+	case http.StatusOK:
+	default:
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+	}
+
+	var pairs []consulPair
+	if err := json.NewDecoder(resp.Body).Decode(&pairs); err != nil {
+		return nil, 0, err // nolint:wrapcheck
+	}
+
+	for _, pair := range pairs {
+		if pair.Value == nil {
+			continue // folders don't have values
+		}
+		values[pair.Key] = string(pair.Value)
+	}
+
+	return values, newIndex, nil
+}
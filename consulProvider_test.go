@@ -0,0 +1,231 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeConsul is a stand-in of the Consul KV API which supports recursive reads and blocking queries.
+type fakeConsul struct {
+	mu      sync.Mutex
+	index   uint64
+	pairs   map[string]string
+	changed chan struct{}
+}
+
+func newFakeConsul(pairs map[string]string) *fakeConsul {
+	return &fakeConsul{index: 1, pairs: pairs, changed: make(chan struct{})}
+}
+
+func (fc *fakeConsul) put(key, value string) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.pairs[key] = value
+	fc.index++
+	close(fc.changed)
+	fc.changed = make(chan struct{})
+}
+
+func (fc *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Consul-Token") != "secret" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	fc.mu.Lock()
+	index, changed := fc.index, fc.changed
+	fc.mu.Unlock()
+
+	if waitIndex, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); waitIndex == index {
+		wait, _ := time.ParseDuration(r.URL.Query().Get("wait"))
+		select {
+		case <-changed:
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	pairs := []map[string]any{}
+	for key, val := range fc.pairs {
+		if strings.HasPrefix(key, prefix) {
+			pairs = append(pairs, map[string]any{"Key": key, "Value": []byte(val)})
+		}
+	}
+
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(fc.index, 10))
+	if len(pairs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(append(pairs, map[string]any{"Key": prefix, "Value": nil}))
+}
+
+type _consulCfg struct {
+	Host  string   `kv:"db/host"`
+	Port  int      `kv:"db/port"`
+	Peers []string `kv:"peers"`
+}
+
+func TestConsulProvider(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(newFakeConsul(map[string]string{
+		"billing/db/host": "db.internal",
+		"billing/db/port": "5432",
+		"billing/peers":   "a;b",
+		"other/db/host":   "other",
+	}))
+	defer srv.Close()
+
+	got, err := New[_consulCfg](NewConsulProvider(srv.URL+"/", "/billing/", WithConsulToken("secret")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &_consulCfg{Host: "db.internal", Port: 5432, Peers: []string{"a", "b"}}, got)
+
+	p := NewConsulProvider(srv.URL, "missing", WithConsulToken("secret"))
+	if err := p.Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = p.Provide(reflect.StructField{Tag: `kv:"db/host"`, Type: reflect.TypeOf("")}, reflect.ValueOf(new(string)).Elem())
+	assert(t, true, errors.Is(err, ErrEmptyValue))
+
+	err = NewConsulProvider(srv.URL, "billing").Init(nil)
+	assert(t, "ConsulProvider.Init: unexpected status: 403 Forbidden", err.Error())
+}
+
+func TestConsulProvider_WaitForChange(t *testing.T) {
+	t.Parallel()
+
+	consul := newFakeConsul(map[string]string{"billing/db/host": "db.internal"})
+	srv := httptest.NewServer(consul)
+	defer srv.Close()
+
+	p := NewConsulProvider(srv.URL, "billing", WithConsulToken("secret"), WithConsulWaitTime(time.Second))
+
+	type cfg struct {
+		Host string `kv:"db/host"`
+	}
+
+	got, err := New[cfg](p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "db.internal", got.Host)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		consul.put("billing/db/host", "db2.internal")
+	}()
+
+	if err := p.WaitForChange(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var host string
+	field := reflect.StructField{Tag: `kv:"db/host"`, Type: reflect.TypeOf(host)}
+	if err := p.Provide(field, reflect.ValueOf(&host).Elem()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "db2.internal", host)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = p.WaitForChange(ctx)
+	assert(t, true, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestConsulProvider_WaitForChangeWithoutIndex(t *testing.T) {
+	t.Parallel()
+
+	// the server doesn't support blocking queries and doesn't return X-Consul-Index
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`[{"Key": "billing/db/host", "Value": "ZGIuaW50ZXJuYWw="}]`))
+	}))
+	defer srv.Close()
+
+	p := NewConsulProvider(srv.URL, "billing")
+	if err := p.Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := p.WaitForChange(ctx)
+	assert(t, true, errors.Is(err, context.DeadlineExceeded))
+
+	// Init and the first query, the next one is delayed by a second
+	assert(t, true, calls.Load() >= 2 && calls.Load() <= 3)
+}
+
+func TestConsulProvider_Reload(t *testing.T) {
+	t.Parallel()
+
+	consul := newFakeConsul(map[string]string{"billing/db/host": "db.internal"})
+	srv := httptest.NewServer(consul)
+	defer srv.Close()
+
+	p := NewConsulProvider(srv.URL, "billing", WithConsulToken("secret"), WithConsulWaitTime(time.Second))
+	if err := p.Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		consul.put("billing/db/host", "db2.internal")
+	}()
+
+	changed, err := p.Reload(context.Background())
+	assert(t, nil, err)
+	assert(t, true, changed)
+
+	// the wait time is over without changes
+	changed, err = p.Reload(context.Background())
+	assert(t, nil, err)
+	assert(t, false, changed)
+}
+
+func TestConsulProvider_WatchWithoutIndex(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`[{"Key": "billing/db/host", "Value": "ZGIuaW50ZXJuYWw="}]`))
+	}))
+	defer srv.Close()
+
+	p := NewConsulProvider(srv.URL, "billing")
+	if err := p.Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := Watch(ctx, p, 50*time.Millisecond, func() { t.Error("unexpected change") }, nil)
+	assert(t, true, errors.Is(err, context.DeadlineExceeded))
+
+	// the interval limits the rate of requests: about 4 reloads instead of thousands
+	assert(t, true, calls.Load() >= 2 && calls.Load() <= 6)
+}
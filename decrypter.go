@@ -0,0 +1,142 @@
+package configuration
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	encryptedPrefix = "ENC["
+	encryptedSuffix = "]"
+)
+
+var ErrDecryption = errors.New("decryption failed")
+
+// Decrypter decrypts payloads of `ENC[<payload>]` values.
+type Decrypter interface {
+	Decrypt(payload string) (string, error)
+}
+
+// WithDecrypter decrypts `ENC[...]` payloads in values of all providers before they are set into fields.
+// Payloads may be a part of the value: `postgres://app:ENC[...]@db/app`.
+// Decryption happens after interpolation (see WithInterpolation). Fields of struct and map types are set as is.
+func WithDecrypter(d Decrypter) ConfiguratorOption {
+	return func(o *configuratorOptions) {
+		o.decrypter = d
+	}
+}
+
+// decryptValue replaces every `ENC[...]` payload of the value by its plaintext.
+func decryptValue(val string, d Decrypter) (string, error) {
+	var sb strings.Builder
+
+	for {
+		start := strings.Index(val, encryptedPrefix)
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(val[start:], encryptedSuffix)
+		if end < 0 {
+			return "", fmt.Errorf("%w: encrypted value is not closed", ErrSyntax)
+		}
+		end += start
+
+		plaintext, err := d.Decrypt(val[start+len(encryptedPrefix) : end])
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrDecryption, err)
+		}
+
+		sb.WriteString(val[:start])
+		sb.WriteString(plaintext)
+		val = val[end+len(encryptedSuffix):]
+	}
+	sb.WriteString(val)
+
+	return sb.String(), nil
+}
+
+// NewAESGCMDecrypter creates the Decrypter for payloads which are base64 encoded nonce followed by AES-GCM ciphertext.
+// The key must be 16, 24 or 32 bytes long (AES-128, AES-192 or AES-256).
+func NewAESGCMDecrypter(key []byte) (*AESGCMDecrypter, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	return &AESGCMDecrypter{aead: aead}, nil
+}
+
+// NewAESGCMDecrypterFromEnv creates the AES-GCM Decrypter with the base64 encoded key from the env variable.
+func NewAESGCMDecrypterFromEnv(name string) (*AESGCMDecrypter, error) {
+	encodedKey, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: env variable [%s] is not set", ErrEmptyValue, name)
+	}
+
+	return newAESGCMDecrypterFromBase64(encodedKey)
+}
+
+// NewAESGCMDecrypterFromFile creates the AES-GCM Decrypter with the base64 encoded key from the file.
+func NewAESGCMDecrypterFromFile(fileName string) (*AESGCMDecrypter, error) {
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	return newAESGCMDecrypterFromBase64(string(b))
+}
+
+func newAESGCMDecrypterFromBase64(encodedKey string) (*AESGCMDecrypter, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+
+	return NewAESGCMDecrypter(key)
+}
+
+type AESGCMDecrypter struct {
+	aead cipher.AEAD
+}
+
+func (d *AESGCMDecrypter) Decrypt(payload string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", err // nolint:wrapcheck
+	}
+
+	if len(b) < d.aead.NonceSize() {
+		return "", fmt.Errorf("%w: payload is too short", ErrInvalidInput)
+	}
+
+	plaintext, err := d.aead.Open(nil, b[:d.aead.NonceSize()], b[d.aead.NonceSize():], nil)
+	if err != nil {
+		return "", err // nolint:wrapcheck
+	}
+
+	return string(plaintext), nil
+}
+
+// Encrypt returns `ENC[...]` value of the plaintext which may be put into configuration files.
+func (d *AESGCMDecrypter) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, d.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err // nolint:wrapcheck
+	}
+
+	b := d.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(b) + encryptedSuffix, nil
+}
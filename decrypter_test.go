@@ -0,0 +1,155 @@
+package configuration
+
+import (
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var _testAESKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestDecrypter(t *testing.T) {
+	d, err := NewAESGCMDecrypter(_testAESKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	password, _ := d.Encrypt("s3cr3t")
+	user, _ := d.Encrypt("app")
+	t.Setenv("DECRYPTER_PASSWORD", password)
+	t.Setenv("DECRYPTER_USER", user)
+
+	type cfg struct {
+		Password string `env:"DECRYPTER_PASSWORD"`
+		DSN      string `default:"postgres://${DECRYPTER_USER}:${.Password}@db/app"`
+		Plain    string `default:"plain"`
+	}
+
+	got, err := NewConfigurator[cfg](NewEnvProvider(), NewDefaultProvider()).
+		SetOptions(WithInterpolation(), WithDecrypter(d)).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "s3cr3t", got.Password)
+	assert(t, "postgres://app:s3cr3t@db/app", got.DSN)
+	assert(t, "plain", got.Plain)
+}
+
+func TestDecrypter_JSON(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewAESGCMDecrypter(_testAESKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token, _ := d.Encrypt("token")
+	doc := `{"api": {"token": "` + token + `", "keys": ["a", "` + token + `"]}}`
+
+	type cfg struct {
+		Token string   `file_json:"api.token"`
+		Keys  []string `file_json:"api.keys"`
+	}
+
+	got, err := NewConfigurator[cfg](NewJSONFileProviderFromBytes([]byte(doc))).
+		SetOptions(WithDecrypter(d)).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Token: "token", Keys: []string{"a", "token"}}, got)
+
+	// values aren't decrypted without the option
+	got, err = New[cfg](NewJSONFileProviderFromBytes([]byte(doc)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, token, got.Token)
+}
+
+func TestDecrypter_INISlice(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewAESGCMDecrypter(_testAESKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	peer, _ := d.Encrypt("b")
+	fileName := filepath.Join(t.TempDir(), "config.ini")
+	writeTestFile(t, fileName, "[server]\npeer = a\npeer = "+peer+"\npeer = c\n", 0o600)
+
+	type cfg struct {
+		Peers []string `file_ini:"server.peer"`
+	}
+
+	got, err := NewConfigurator[cfg](NewINIFileProvider(fileName)).
+		SetOptions(WithDecrypter(d)).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, []string{"a", "b", "c"}, got.Peers)
+}
+
+func TestDecrypter_Errors(t *testing.T) {
+	t.Parallel()
+
+	d, _ := NewAESGCMDecrypter(_testAESKey)
+	other, _ := NewAESGCMDecrypter([]byte("fedcba9876543210"))
+	encrypted, _ := other.Encrypt("s3cr3t")
+
+	type cfg struct {
+		Password string `file_json:"password"`
+	}
+
+	_, err := NewConfigurator[cfg](NewJSONFileProviderFromBytes([]byte(`{"password": "` + encrypted + `"}`))).
+		SetOptions(WithDecrypter(d)).
+		InitValues()
+	assert(t, true, errors.Is(err, ErrDecryption))
+	assert(t, "field [Password]: decryption failed: cipher: message authentication failed", err.Error())
+
+	_, err = decryptValue("ENC[abc", d)
+	assert(t, "syntax error: encrypted value is not closed", err.Error())
+
+	_, err = d.Decrypt(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert(t, "invalid input: payload is too short", err.Error())
+
+	_, err = NewAESGCMDecrypter([]byte("short"))
+	assert(t, "crypto/aes: invalid key size 5", err.Error())
+}
+
+func TestAESGCMDecrypterFromEnvAndFile(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(_testAESKey)
+	t.Setenv("DECRYPTER_KEY", key)
+
+	fromEnv, err := NewAESGCMDecrypterFromEnv("DECRYPTER_KEY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	writeTestFile(t, keyFile, key+"\n", 0o600)
+
+	fromFile, err := NewAESGCMDecrypterFromFile(keyFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encrypted, _ := fromEnv.Encrypt("s3cr3t")
+	assert(t, true, strings.HasPrefix(encrypted, "ENC["))
+
+	plaintext, err := decryptValue(encrypted, fromFile)
+	assert(t, nil, err)
+	assert(t, "s3cr3t", plaintext)
+
+	_, err = NewAESGCMDecrypterFromEnv("DECRYPTER_MISSING_KEY")
+	assert(t, "empty value: env variable [DECRYPTER_MISSING_KEY] is not set", err.Error())
+
+	t.Setenv("DECRYPTER_KEY", "not base64")
+	_, err = NewAESGCMDecrypterFromEnv("DECRYPTER_KEY")
+	assert(t, true, strings.HasPrefix(err.Error(), "key: illegal base64 data"))
+}
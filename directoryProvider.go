@@ -0,0 +1,124 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+const (
+	DirectoryProviderName = `DirectoryProvider`
+	DirectoryProviderTag  = `file_secret`
+)
+
+var (
+	ErrNotADirectory    = errors.New("not a directory")
+	ErrWorldReadable    = errors.New("file is readable by others")
+	ErrPathOutsideOfDir = errors.New("path is outside of the directory")
+)
+
+type DirectoryProviderOption func(*directoryProvider)
+
+// WithStrictPermissions makes the provider refuse files which are readable by others (e.g. 0644).
+func WithStrictPermissions() DirectoryProviderOption {
+	return func(dp *directoryProvider) {
+		dp.strict = true
+	}
+}
+
+// NewDirectoryProvider creates provider which reads values from files inside the directory, one file per key
+// (e.g. Docker or Kubernetes secrets mounted into `/run/secrets`). The file name is taken from `file_secret` tag.
+// nolint:revive
+func NewDirectoryProvider(dir string, opts ...DirectoryProviderOption) directoryProvider {
+	dp := directoryProvider{dir: dir}
+
+	for _, f := range opts {
+		f(&dp)
+	}
+
+	return dp
+}
+
+type directoryProvider struct {
+	dir    string
+	strict bool
+}
+
+func (directoryProvider) Name() string {
+	return DirectoryProviderName
+}
+
+func (directoryProvider) Tag() string {
+	return DirectoryProviderTag
+}
+
+func (dp directoryProvider) Init(_ any) error {
+	info, err := os.Stat(dp.dir)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w", DirectoryProviderName, err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s.Init: %w: %s", DirectoryProviderName, ErrNotADirectory, dp.dir)
+	}
+
+	return nil
+}
+
+func (dp directoryProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	key := field.Tag.Get(DirectoryProviderTag)
+	if len(key) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", DirectoryProviderName)
+	}
+
+	// nested paths are allowed (`db/password`), but not the ones leading outside of the directory
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return fmt.Errorf("%s: %w: %s", DirectoryProviderName, ErrPathOutsideOfDir, key)
+	}
+
+	valStr, err := readSecretFile(filepath.Join(dp.dir, filepath.FromSlash(key)), dp.strict)
+	if err != nil {
+		return fmt.Errorf("%s: %w", DirectoryProviderName, err)
+	}
+
+	return SetField(field, v, valStr)
+}
+
+// readSecretFile reads the whole file trimming trailing newlines.
+// If strict is set, files readable by others are refused. Permissions are checked on the opened file,
+// so it can't be replaced between the check and the reading.
+func readSecretFile(path string, strict bool) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err // nolint:wrapcheck
+	}
+	defer f.Close()
+
+	if strict {
+		info, err := f.Stat()
+		if err != nil {
+			return "", err // nolint:wrapcheck
+		}
+
+		if info.Mode().Perm()&0o004 != 0 {
+			return "", fmt.Errorf("%w: %s", ErrWorldReadable, path)
+		}
+	}
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return "", err // nolint:wrapcheck
+	}
+
+	valStr := strings.TrimRight(string(b), "\r\n")
+	if len(valStr) == 0 {
+		return "", ErrEmptyValue
+	}
+
+	return valStr, nil
+}
@@ -0,0 +1,128 @@
+package configuration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// umask may limit permissions set by os.WriteFile
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDirectoryProvider(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "db_password"), "secret\n", 0o600)
+	writeTestFile(t, filepath.Join(dir, "db", "user"), "admin\r\n\n", 0o600)
+	writeTestFile(t, filepath.Join(dir, "port"), "5432", 0o644)
+
+	type cfg struct {
+		Password string `file_secret:"db_password"`
+		User     string `file_secret:"db/user"`
+		Port     int    `file_secret:"port"`
+		Missing  string `file_secret:"missing" default:"fallback"`
+	}
+
+	got, err := New[cfg](NewDirectoryProvider(dir), NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &cfg{Password: "secret", User: "admin", Port: 5432, Missing: "fallback"}, got)
+}
+
+func TestDirectoryProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "public"), "value", 0o644)
+	writeTestFile(t, filepath.Join(dir, "empty"), "\n", 0o600)
+
+	tests := map[string]struct {
+		obj      any
+		expected error
+	}{
+		"world readable": {
+			obj: &struct {
+				Name string `file_secret:"public"`
+			}{},
+			expected: ErrWorldReadable,
+		},
+		"outside of dir": {
+			obj: &struct {
+				Name string `file_secret:"../public"`
+			}{},
+			expected: ErrPathOutsideOfDir,
+		},
+		"empty file": {
+			obj: &struct {
+				Name string `file_secret:"empty"`
+			}{},
+			expected: ErrEmptyValue,
+		},
+		"no file": {
+			obj: &struct {
+				Name string `file_secret:"nope"`
+			}{},
+			expected: os.ErrNotExist,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fieldType := reflect.TypeOf(test.obj).Elem().Field(0)
+			fieldVal := reflect.ValueOf(test.obj).Elem().Field(0)
+
+			err := NewDirectoryProvider(dir, WithStrictPermissions()).Provide(fieldType, fieldVal)
+			assert(t, true, errors.Is(err, test.expected), err.Error())
+		})
+	}
+}
+
+func TestDirectoryProvider_Init(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "file"), "value", 0o600)
+
+	err := NewDirectoryProvider(filepath.Join(dir, "file")).Init(nil)
+	assert(t, true, errors.Is(err, ErrNotADirectory))
+
+	err = NewDirectoryProvider(filepath.Join(dir, "nope")).Init(nil)
+	assert(t, true, errors.Is(err, os.ErrNotExist))
+}
+
+func TestDirectoryProvider_EmptyKey(t *testing.T) {
+	t.Parallel()
+
+	testObj := struct {
+		Name string `file_secret:""`
+	}{}
+
+	fieldType := reflect.TypeOf(&testObj).Elem().Field(0)
+	fieldVal := reflect.ValueOf(&testObj).Elem().Field(0)
+
+	err := NewDirectoryProvider(".").Provide(fieldType, fieldVal)
+	assert(t, "DirectoryProvider: key is empty", err.Error())
+}
@@ -10,15 +10,34 @@ import (
 const (
 	EnvProviderName = `EnvProvider`
 	EnvProviderTag  = `env`
+	envFileSuffix   = `_FILE`
 )
 
+type EnvProviderOption func(*envProvider)
+
+// WithFileFallback makes the provider read the value from the file at path `<KEY>_FILE`
+// when `<KEY>` is not set (the convention used by Docker images for secrets).
+func WithFileFallback() EnvProviderOption {
+	return func(ep *envProvider) {
+		ep.fileFallback = true
+	}
+}
+
 // NewEnvProvider creates provider which sets values from ENV variables (gets variable name from `env` tag)
 // nolint:revive
-func NewEnvProvider() envProvider {
-	return envProvider{}
+func NewEnvProvider(opts ...EnvProviderOption) envProvider {
+	ep := envProvider{}
+
+	for _, f := range opts {
+		f(&ep)
+	}
+
+	return ep
 }
 
-type envProvider struct{}
+type envProvider struct {
+	fileFallback bool
+}
 
 func (envProvider) Name() string {
 	return EnvProviderName
@@ -40,7 +59,16 @@ func (ep envProvider) Provide(field reflect.StructField, v reflect.Value) error
 	}
 
 	valStr, ok := os.LookupEnv(strings.ToUpper(key))
-	if !ok || len(valStr) == 0 {
+	if (!ok || len(valStr) == 0) && ep.fileFallback {
+		if path, ok := os.LookupEnv(strings.ToUpper(key) + envFileSuffix); ok && len(path) > 0 {
+			var err error
+			if valStr, err = readSecretFile(path, false); err != nil {
+				return fmt.Errorf("%s: %w", EnvProviderName, err)
+			}
+		}
+	}
+
+	if len(valStr) == 0 {
 		return fmt.Errorf("%s: %w", EnvProviderName, ErrEmptyValue)
 	}
 
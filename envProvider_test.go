@@ -2,6 +2,9 @@
 package configuration
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -59,3 +62,48 @@ func TestEnvProvider_EmptyKey(t *testing.T) {
 	err := provider.Provide(fieldType, fieldVal)
 	assert(t, "EnvProvider: key is empty", err.Error())
 }
+
+func TestEnvProvider_FileFallback(t *testing.T) {
+	type testStruct struct {
+		Password string `env:"ENV_PASSWORD"`
+		User     string `env:"ENV_USER"`
+	}
+	testObj := testStruct{}
+
+	path := filepath.Join(t.TempDir(), "password")
+	writeTestFile(t, path, "secret\n", 0o600)
+
+	t.Setenv("ENV_PASSWORD_FILE", path)
+	t.Setenv("ENV_USER", "admin")
+	t.Setenv("ENV_USER_FILE", path)
+
+	provider := NewEnvProvider(WithFileFallback())
+	for i := range 2 {
+		fieldType := reflect.TypeOf(&testObj).Elem().Field(i)
+		fieldVal := reflect.ValueOf(&testObj).Elem().Field(i)
+
+		if err := provider.Provide(fieldType, fieldVal); err != nil {
+			t.Fatalf("cannot set value: %v", err)
+		}
+	}
+
+	assert(t, testStruct{Password: "secret", User: "admin"}, testObj)
+}
+
+func TestEnvProvider_FileFallbackErrors(t *testing.T) {
+	type testStruct struct {
+		Password string `env:"ENV_PASSWORD"`
+	}
+	testObj := testStruct{}
+
+	fieldType := reflect.TypeOf(&testObj).Elem().Field(0)
+	fieldVal := reflect.ValueOf(&testObj).Elem().Field(0)
+
+	t.Setenv("ENV_PASSWORD_FILE", filepath.Join(t.TempDir(), "nope"))
+
+	err := NewEnvProvider().Provide(fieldType, fieldVal)
+	assert(t, true, errors.Is(err, ErrEmptyValue), "fallback must be disabled by default")
+
+	err = NewEnvProvider(WithFileFallback()).Provide(fieldType, fieldVal)
+	assert(t, true, errors.Is(err, os.ErrNotExist))
+}
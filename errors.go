@@ -13,4 +13,11 @@ var (
 	ErrNoProviders           = errors.New("no providers")
 	ErrProviderNameCollision = errors.New("provider name collision")
 	ErrProviderTagCollision  = errors.New("provider tag collision")
+	ErrNoSubcommand          = errors.New("no subcommand")
+	ErrUnknownSubcommand     = errors.New("unknown subcommand")
+	ErrSyntax                = errors.New("syntax error")
+
+	// ErrProviderFailed is wrapped by providers which source has failed (e.g. the network error).
+	// Such errors are returned by InitValues instead of trying the next provider.
+	ErrProviderFailed = errors.New("provider failed")
 )
@@ -0,0 +1,184 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ExecProviderName = `ExecProvider`
+	ExecProviderTag  = `exec`
+)
+
+var ErrCommandNotAllowed = errors.New("command is not allowed")
+
+type ExecProviderOption func(*ExecProvider)
+
+// WithExecTimeout sets the timeout of every command (10s by default).
+func WithExecTimeout(timeout time.Duration) ExecProviderOption {
+	return func(ep *ExecProvider) {
+		ep.timeout = timeout
+	}
+}
+
+// NewExecProvider creates new provider which runs the command of `exec:"pass show db/password"` tag
+// and sets its output without trailing newlines. The command is split into arguments by spaces,
+// quotes group arguments; no shell is involved. Only executables from the allow-list may be run,
+// they are compared with the first argument as is (`pass` or `/usr/bin/pass`).
+// Identical commands are run once per Init, stderr of failed commands is included into errors.
+// Commands with empty output are reported as ErrEmptyValue, so the next provider is used,
+// other failures (not allowed commands, non-zero exit codes, timeouts) wrap ErrProviderFailed.
+func NewExecProvider(allowed []string, opts ...ExecProviderOption) *ExecProvider {
+	ep := &ExecProvider{
+		allowed: allowed,
+		timeout: defaultTimeout,
+		cache:   map[string]execResult{},
+	}
+
+	for _, f := range opts {
+		f(ep)
+	}
+
+	return ep
+}
+
+type ExecProvider struct {
+	allowed []string
+	timeout time.Duration
+	cache   map[string]execResult
+}
+
+type execResult struct {
+	out string
+	err error
+}
+
+func (*ExecProvider) Name() string {
+	return ExecProviderName
+}
+
+func (*ExecProvider) Tag() string {
+	return ExecProviderTag
+}
+
+func (ep *ExecProvider) Init(_ any) error {
+	clear(ep.cache)
+	return nil
+}
+
+func (ep *ExecProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	command := field.Tag.Get(ExecProviderTag)
+	if len(command) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", ExecProviderName)
+	}
+
+	res, ok := ep.cache[command]
+	if !ok {
+		res.out, res.err = ep.run(command)
+		ep.cache[command] = res
+	}
+
+	if errors.Is(res.err, ErrEmptyValue) {
+		return fmt.Errorf("%s: %w", ExecProviderName, res.err)
+	}
+
+	if res.err != nil {
+		// the command has failed, so the next provider (e.g. the default) must not hide it
+		return fmt.Errorf("%s: %w: %w", ExecProviderName, ErrProviderFailed, res.err)
+	}
+
+	return SetField(field, v, res.out)
+}
+
+func (ep *ExecProvider) run(command string) (string, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains(ep.allowed, args[0]) {
+		return "", fmt.Errorf("%w: %s", ErrCommandNotAllowed, args[0])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ep.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) // nolint:gosec
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+
+		if msg := strings.TrimSpace(stderr.String()); len(msg) > 0 {
+			return "", fmt.Errorf("[%s]: %w: %s", command, err, msg)
+		}
+
+		return "", fmt.Errorf("[%s]: %w", command, err)
+	}
+
+	out := strings.TrimRight(stdout.String(), "\r\n")
+	if len(out) == 0 {
+		return "", fmt.Errorf("[%s]: %w", command, ErrEmptyValue)
+	}
+
+	return out, nil
+}
+
+// splitCommand splits the command into arguments by spaces, single and double quotes group arguments.
+func splitCommand(command string) ([]string, error) {
+	var (
+		args  []string
+		arg   strings.Builder
+		inArg bool
+		quote rune
+		flush = func() {
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		}
+	)
+
+	for _, r := range command {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+
+		case quote != 0:
+			arg.WriteRune(r)
+
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+
+		case r == ' ' || r == '\t':
+			flush()
+
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("%w: quote is not closed: %s", ErrSyntax, command)
+	}
+	flush()
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty command", ErrSyntax)
+	}
+
+	return args, nil
+}
@@ -0,0 +1,134 @@
+package configuration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExecProvider(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Password string   `exec:"sh -c 'echo s3cr3t'"`
+		Nonce    string   `exec:"sh -c 'date +%s%N'"`
+		Nonce2   string   `exec:"sh -c 'date +%s%N'"`
+		Keys     []string `exec:"printf \"a;b\n\n\""`
+		Missing  string   `exec:"true" default:"none"`
+	}
+
+	p := NewExecProvider([]string{"sh", "printf", "true"})
+
+	got, err := New[cfg](p, NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "s3cr3t", got.Password)
+	assert(t, got.Nonce, got.Nonce2) // the command is run once
+	assert(t, []string{"a", "b"}, got.Keys)
+	assert(t, "none", got.Missing)
+
+	// the cache is cleared by Init
+	got2, err := New[cfg](p, NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, true, got.Nonce != got2.Nonce)
+}
+
+func TestExecProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		command  string
+		opts     []ExecProviderOption
+		expected string
+		err      error
+	}{
+		"not allowed": {
+			command:  "cat /etc/passwd",
+			expected: "ExecProvider: provider failed: command is not allowed: cat",
+			err:      ErrCommandNotAllowed,
+		},
+		"stderr": {
+			command:  "sh -c 'echo oops >&2; exit 3'",
+			expected: "ExecProvider: provider failed: [sh -c 'echo oops >&2; exit 3']: exit status 3: oops",
+			err:      ErrProviderFailed,
+		},
+		"timeout": {
+			command:  "sleep 5",
+			opts:     []ExecProviderOption{WithExecTimeout(20 * time.Millisecond)},
+			expected: "ExecProvider: provider failed: [sleep 5]: context deadline exceeded",
+			err:      ErrProviderFailed,
+		},
+		"empty output": {
+			command:  "true",
+			expected: "ExecProvider: [true]: empty value",
+			err:      ErrEmptyValue,
+		},
+		"quote": {
+			command:  "sh -c 'echo",
+			expected: "ExecProvider: provider failed: syntax error: quote is not closed: sh -c 'echo",
+			err:      ErrSyntax,
+		},
+	}
+
+	for name, test := range testCases {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var val string
+			field := reflect.StructField{
+				Name: "Val",
+				Type: reflect.TypeOf(val),
+				Tag:  reflect.StructTag(`exec:"` + test.command + `"`),
+			}
+
+			p := NewExecProvider([]string{"sh", "sleep", "true"}, test.opts...)
+			err := p.Provide(field, reflect.ValueOf(&val).Elem())
+			assert(t, test.expected, err.Error())
+
+			if test.err != nil {
+				assert(t, true, errors.Is(err, test.err))
+			}
+		})
+	}
+}
+
+func TestExecProvider_FailedWithDefault(t *testing.T) {
+	t.Parallel()
+
+	type notAllowed struct {
+		Val string `exec:"cat /etc/passwd" default:"fallback"`
+	}
+
+	_, err := New[notAllowed](NewExecProvider([]string{"sh"}), NewDefaultProvider())
+	assert(t, "field [Val]: ExecProvider: provider failed: command is not allowed: cat", err.Error())
+	assert(t, true, errors.Is(err, ErrProviderFailed))
+
+	type exitCode struct {
+		Val string `exec:"sh -c 'exit 1'" default:"fallback"`
+	}
+
+	_, err = New[exitCode](NewExecProvider([]string{"sh"}), NewDefaultProvider())
+	assert(t, "field [Val]: ExecProvider: provider failed: [sh -c 'exit 1']: exit status 1", err.Error())
+	assert(t, true, errors.Is(err, ErrProviderFailed))
+}
+
+func TestSplitCommand(t *testing.T) {
+	t.Parallel()
+
+	args, err := splitCommand(`op read  "op://vault/db/pass word" --no-newline ''`)
+	assert(t, nil, err)
+	assert(t, []string{"op", "read", "op://vault/db/pass word", "--no-newline", ""}, args)
+
+	args, err = splitCommand(`a'b c'd`)
+	assert(t, nil, err)
+	assert(t, []string{"ab cd"}, args)
+
+	_, err = splitCommand("  ")
+	assert(t, "syntax error: empty command", err.Error())
+}
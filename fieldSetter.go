@@ -10,6 +10,13 @@ import (
 
 const sliceSeparator = ";"
 
+var fieldSetterType = reflect.TypeOf((*FieldSetter)(nil)).Elem()
+
+// isFieldSetter reports whether values of type t are set by their own FieldSetter implementation.
+func isFieldSetter(t reflect.Type) bool {
+	return t.Implements(fieldSetterType) || reflect.PointerTo(t).Implements(fieldSetterType)
+}
+
 // FieldSetter interface
 type FieldSetter interface {
 	SetField(field reflect.StructField, val reflect.Value, valStr string) error
@@ -17,6 +24,8 @@ type FieldSetter interface {
 
 // SetField sets field with `valStr` value (and converts it into the proper type beforehand)
 func SetField(field reflect.StructField, val reflect.Value, valStr string) error {
+	captureRaw(val, valStr)
+
 	if val.CanInterface() {
 		if fs, ok := val.Addr().Interface().(FieldSetter); ok {
 			return fs.SetField(field, val, valStr) // nolint:wrapcheck
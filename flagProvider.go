@@ -13,6 +13,7 @@ const (
 	FlagProviderName = `FlagProvider`
 	FlagProviderTag  = `flag`
 	flagSeparator    = "|"
+	aliasSeparator   = ","
 )
 
 type FlagProviderOption func(*flagProvider)
@@ -21,9 +22,10 @@ type FlagProviderOption func(*flagProvider)
 // nolint:revive
 func NewFlagProvider(opts ...FlagProviderOption) flagProvider {
 	fp := flagProvider{
-		flagsValues: map[string]func() *string{},
-		flags:       map[string]*flagData{},
-		flagSet:     flag.CommandLine,
+		flagsValues:    map[string]func() *string{},
+		flags:          map[string]*flagData{},
+		registeredTags: map[string]struct{}{},
+		flagSet:        flag.CommandLine,
 	}
 
 	for _, f := range opts {
@@ -46,7 +48,16 @@ func (fp flagProvider) Init(ptr any) (err error) {
 		return err
 	}
 
-	if err := fp.flagSet.Parse(os.Args[1:]); err != nil {
+	if fp.generatedUsage {
+		fp.setUsage(reflect.TypeOf(ptr))
+	}
+
+	args := fp.args
+	if args == nil {
+		args = os.Args[1:]
+	}
+
+	if err := fp.flagSet.Parse(args); err != nil {
 		return fmt.Errorf("%s.Init: %w", FlagProviderName, err)
 	}
 
@@ -59,6 +70,17 @@ type FlagSet interface {
 	String(name string, value string, usage string) *string
 }
 
+// argsFlagSet is implemented by flag sets which keep non-flag arguments (e.g. *flag.FlagSet).
+type argsFlagSet interface {
+	Args() []string
+}
+
+// varFlagSet is implemented by flag sets which accept a custom flag.Value (e.g. *flag.FlagSet).
+// It's used to accumulate repeated flags for slice fields.
+type varFlagSet interface {
+	Var(value flag.Value, name string, usage string)
+}
+
 // WithFlagSet allows the flag.FlagSet to be provided to NewFlagProvider.
 // This allows compatibility with other flag parsing utilities.
 func WithFlagSet(s FlagSet) FlagProviderOption {
@@ -67,14 +89,33 @@ func WithFlagSet(s FlagSet) FlagProviderOption {
 	}
 }
 
+// WithArgs sets arguments to parse instead of os.Args[1:].
+func WithArgs(args []string) FlagProviderOption {
+	return func(fp *flagProvider) {
+		fp.args = args
+	}
+}
+
+// WithGeneratedUsage replaces the usage (-help) of *flag.FlagSet or *POSIXFlagSet
+// with the one generated by PrintUsage.
+func WithGeneratedUsage() FlagProviderOption {
+	return func(fp *flagProvider) {
+		fp.generatedUsage = true
+	}
+}
+
 type flagProvider struct {
-	flagsValues map[string]func() *string
-	flags       map[string]*flagData
-	flagSet     FlagSet
+	flagsValues    map[string]func() *string
+	flags          map[string]*flagData
+	registeredTags map[string]struct{}
+	flagSet        FlagSet
+	args           []string
+	generatedUsage bool
 }
 
 type flagData struct {
 	key        string
+	aliases    []string
 	defaultVal string
 	usage      string
 }
@@ -121,26 +162,112 @@ func (fp flagProvider) setFlagCallbacks(field reflect.StructField) error {
 		return err
 	}
 
-	if _, ok := fp.flagsValues[fd.key]; ok {
-		return fmt.Errorf("%w: %s", ErrTagNotUnique, fd.key)
+	for _, name := range append([]string{fd.key}, fd.aliases...) {
+		if _, ok := fp.flagsValues[name]; ok {
+			return fmt.Errorf("%w: %s", ErrTagNotUnique, name)
+		}
 	}
 	fp.flags[fd.key] = fd
+	usage := fp.usage(field, fd)
+
+	vs, ok := fp.flagSet.(varFlagSet)
+	if !ok {
+		if len(fd.aliases) > 0 {
+			return fmt.Errorf("%w: aliases of [%s] require FlagSet with Var method", ErrInvalidInput, fd.key)
+		}
+
+		valStr := fp.flagSet.String(fd.key, fd.defaultVal, usage)
+		fp.flagsValues[fd.key] = func() *string {
+			return valStr
+		}
+
+		return nil
+	}
+
+	var val flag.Value
+	switch {
+	case isRepeatable(field.Type):
+		val = &sliceFlagValue{defaultVal: fd.defaultVal}
 
-	valStr := fp.flagSet.String(fd.key, fd.defaultVal, fd.usage)
-	fp.flagsValues[fd.key] = func() *string {
-		return valStr
+	case len(fd.aliases) > 0 || fp.isPOSIX():
+		val = &scalarFlagValue{val: fd.defaultVal, isBool: fp.isPOSIX() && isBool(field.Type)}
+
+	default:
+		valStr := fp.flagSet.String(fd.key, fd.defaultVal, usage)
+		fp.flagsValues[fd.key] = func() *string {
+			return valStr
+		}
+
+		return nil
+	}
+
+	valFn := func() *string {
+		valStr := val.String()
+		return &valStr
+	}
+
+	vs.Var(val, fd.key, usage)
+	fp.flagsValues[fd.key] = valFn
+
+	for _, alias := range fd.aliases {
+		vs.Var(val, alias, usage)
+		fp.flagsValues[alias] = valFn
 	}
 
 	return nil
 }
 
+// usage decorates the flag description with hints from other registered providers:
+// `Port to listen (env: APP_PORT, default: 8080)`.
+func (fp flagProvider) usage(field reflect.StructField, fd *flagData) string {
+	var hints []string
+
+	if _, ok := fp.registeredTags[EnvProviderTag]; ok {
+		if env := field.Tag.Get(EnvProviderTag); len(env) > 0 {
+			hints = append(hints, "env: "+strings.ToUpper(env))
+		}
+	}
+
+	if _, ok := fp.registeredTags[DefaultProviderTag]; ok && len(fd.defaultVal) == 0 {
+		if def := field.Tag.Get(DefaultProviderTag); len(def) > 0 {
+			hints = append(hints, "default: "+def)
+		}
+	}
+
+	if len(hints) == 0 {
+		return fd.usage
+	}
+
+	hint := "(" + strings.Join(hints, ", ") + ")"
+	if len(fd.usage) == 0 {
+		return hint
+	}
+
+	return fd.usage + " " + hint
+}
+
+func (fp flagProvider) setRegisteredTags(tags map[string]struct{}) {
+	for tag := range tags {
+		fp.registeredTags[tag] = struct{}{}
+	}
+}
+
+// isPOSIX reports whether bool fields may be passed as switches (-v instead of -v=true).
+func (fp flagProvider) isPOSIX() bool {
+	_, ok := fp.flagSet.(*POSIXFlagSet)
+	return ok
+}
+
 func (fp flagProvider) Provide(field reflect.StructField, v reflect.Value) error {
 	fd, err := fp.getFlagData(field)
 	if err != nil {
 		return err
 	}
 
-	fn := fp.flagsValues[fd.key]
+	fn, ok := fp.flagsValues[fd.key]
+	if !ok {
+		return fmt.Errorf("%w: flag [%s] is not registered", ErrEmptyValue, fd.key)
+	}
 
 	val := fn()
 	if val == nil || len(*val) == 0 {
@@ -157,26 +284,97 @@ func (fp flagProvider) getFlagData(field reflect.StructField) (*flagData, error)
 	}
 
 	flagInfo := strings.Split(key, flagSeparator)
-	switch len(flagInfo) {
-	case 3: // nolint:mnd
-		return &flagData{
-			key:        strings.TrimSpace(flagInfo[0]),
-			defaultVal: strings.TrimSpace(flagInfo[1]),
-			usage:      flagInfo[2],
-		}, nil
-
-	case 2: // nolint:mnd
-		return &flagData{
-			key:        strings.TrimSpace(flagInfo[0]),
-			defaultVal: strings.TrimSpace(flagInfo[1]),
-		}, nil
-
-	case 1: // nolint:mnd
-		return &flagData{
-			key: strings.TrimSpace(flagInfo[0]),
-		}, nil
-
-	default:
+	if len(flagInfo) > 3 { // nolint:mnd
 		return nil, fmt.Errorf("wrong flag definition [%s]", key)
 	}
+
+	// the name section may contain aliases: `verbose,v`
+	names := strings.Split(flagInfo[0], aliasSeparator)
+	fd := &flagData{
+		key: strings.TrimSpace(names[0]),
+	}
+
+	for _, alias := range names[1:] {
+		alias = strings.TrimSpace(alias)
+		if len(alias) == 0 {
+			return nil, fmt.Errorf("wrong flag definition [%s]", key)
+		}
+		fd.aliases = append(fd.aliases, alias)
+	}
+
+	if len(flagInfo) > 1 {
+		fd.defaultVal = strings.TrimSpace(flagInfo[1])
+	}
+
+	if len(flagInfo) > 2 { // nolint:mnd
+		fd.usage = flagInfo[2]
+	}
+
+	return fd, nil
+}
+
+// isRepeatable reports whether the flag of type t may be passed several times (--peer a --peer b).
+// Custom FieldSetter types are parsed from a single value, so they aren't accumulated.
+func isRepeatable(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+
+	return !isFieldSetter(t)
+}
+
+// sliceFlagValue accumulates repeated occurrences of a flag preserving their order.
+// Every occurrence is still split by the slice separator, so `--peer "a;b" --peer c` gives [a b c].
+type sliceFlagValue struct {
+	defaultVal string
+	items      []string
+}
+
+func (s *sliceFlagValue) String() string {
+	if s == nil {
+		return ""
+	}
+
+	if len(s.items) == 0 {
+		return s.defaultVal
+	}
+
+	return strings.Join(s.items, sliceSeparator)
+}
+
+func (s *sliceFlagValue) Set(val string) error {
+	s.items = append(s.items, val)
+	return nil
+}
+
+// scalarFlagValue holds a value shared between the flag and its aliases.
+type scalarFlagValue struct {
+	val    string
+	isBool bool
+}
+
+func (s *scalarFlagValue) String() string {
+	if s == nil {
+		return ""
+	}
+
+	return s.val
+}
+
+func (s *scalarFlagValue) Set(val string) error {
+	s.val = val
+	return nil
+}
+
+// IsBoolFlag allows passing bool fields as switches (-v).
+func (s *scalarFlagValue) IsBoolFlag() bool {
+	return s.isBool
+}
+
+func isBool(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Bool
 }
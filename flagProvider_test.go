@@ -123,6 +123,23 @@ func TestGetFlagData(t *testing.T) {
 				usage:      "some usage",
 			},
 		},
+		"key & aliases": {
+			input: struct {
+				Name string `flag:"name, n,nm|defVal"`
+			}{},
+			expected: &flagData{
+				key:        "name",
+				aliases:    []string{"n", "nm"},
+				defaultVal: "defVal",
+			},
+		},
+		"empty alias": {
+			input: struct {
+				Name string `flag:"name,"`
+			}{},
+			expected: nil,
+			hasErr:   true,
+		},
 		"wrong format": {
 			input: struct {
 				Name string `flag:"||||"`
@@ -260,3 +277,147 @@ func TestFlagProvider_CustomFlagSetError(t *testing.T) {
 	err := provider.Init(&testObj)
 	assert(t, "FlagProvider.Init: flagSetMock error", err.Error())
 }
+
+func TestFlagProvider_RepeatedSliceFlag(t *testing.T) {
+	type testStruct struct {
+		Peers []string `flag:"peer||Peer address"`
+		Ports []int    `flag:"port|80;443"`
+	}
+	testObj := testStruct{}
+	os.Args = []string{"smth", "-peer=a", "-peer", "b;c", "--peer=d"}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	provider := NewFlagProvider(WithFlagSet(fs))
+
+	if err := provider.Init(&testObj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range 2 {
+		fieldType := reflect.TypeOf(&testObj).Elem().Field(i)
+		fieldVal := reflect.ValueOf(&testObj).Elem().Field(i)
+
+		if err := provider.Provide(fieldType, fieldVal); err != nil {
+			t.Fatalf("cannot set value: %v", err)
+		}
+	}
+
+	assert(t, []string{"a", "b", "c", "d"}, testObj.Peers)
+	assert(t, []int{80, 443}, testObj.Ports)
+}
+
+func TestFlagProvider_NotRegisteredFlag(t *testing.T) {
+	type testStruct struct {
+		Name string `flag:"not_registered"`
+	}
+	testObj := testStruct{}
+
+	fieldType := reflect.TypeOf(&testObj).Elem().Field(0)
+	fieldVal := reflect.ValueOf(&testObj).Elem().Field(0)
+
+	err := NewFlagProvider().Provide(fieldType, fieldVal)
+	assert(t, true, errors.Is(err, ErrEmptyValue))
+}
+
+func TestFlagProvider_Aliases(t *testing.T) {
+	type testStruct struct {
+		Verbose bool     `flag:"verbose,v||Verbose output"`
+		Debug   *bool    `flag:"debug,d"`
+		Port    int      `flag:"port,p|8080"`
+		Tags    []string `flag:"tag,t"`
+		Name    string   `flag:"name"`
+	}
+	testObj := testStruct{}
+	os.Args = []string{"smth", "-vd", "--tag", "a", "-tb", "-p9090", "--name=test", "--", "rest"}
+
+	fs := NewPOSIXFlagSet("test", flag.ContinueOnError)
+	provider := NewFlagProvider(WithFlagSet(fs))
+
+	if err := provider.Init(&testObj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range reflect.TypeOf(testObj).NumField() {
+		fieldType := reflect.TypeOf(&testObj).Elem().Field(i)
+		fieldVal := reflect.ValueOf(&testObj).Elem().Field(i)
+
+		if err := provider.Provide(fieldType, fieldVal); err != nil {
+			t.Fatalf("cannot set value: %v", err)
+		}
+	}
+
+	assert(t, true, testObj.Verbose)
+	assert(t, true, *testObj.Debug)
+	assert(t, 9090, testObj.Port)
+	assert(t, []string{"a", "b"}, testObj.Tags)
+	assert(t, "test", testObj.Name)
+	assert(t, []string{"rest"}, fs.Args())
+}
+
+func TestFlagProvider_AliasErrors(t *testing.T) {
+	testCases := map[string]struct {
+		obj      any
+		flagSet  FlagSet
+		expected string
+	}{
+		"alias is not unique": {
+			obj: &struct {
+				Name  string `flag:"name,n"`
+				Name2 string `flag:"number,n"`
+			}{},
+			flagSet:  flag.NewFlagSet("test", flag.ContinueOnError),
+			expected: "tag is not unique: n",
+		},
+		"aliases without Var": {
+			obj: &struct {
+				Name string `flag:"name,n"`
+			}{},
+			flagSet:  &_flagSetMock{},
+			expected: "invalid input: aliases of [name] require FlagSet with Var method",
+		},
+	}
+
+	for name, test := range testCases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			os.Args = []string{""}
+
+			err := NewFlagProvider(WithFlagSet(test.flagSet)).Init(test.obj)
+			assert(t, test.expected, err.Error())
+		})
+	}
+}
+
+func TestFlagProvider_UsageHints(t *testing.T) {
+	type testStruct struct {
+		Port    int    `flag:"port||Port to listen" env:"app_port"  default:"8080"`
+		Host    string `flag:"host|localhost"       env:"APP_HOST"  default:"127.0.0.1"`
+		Name    string `flag:"name"                                  default:"app"`
+		Verbose bool   `flag:"verbose|false|Verbose output"`
+	}
+	os.Args = []string{"smth"}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if _, err := New[testStruct](NewFlagProvider(WithFlagSet(fs)), NewEnvProvider(), NewDefaultProvider()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, "Port to listen (env: APP_PORT, default: 8080)", fs.Lookup("port").Usage)
+	assert(t, "(env: APP_HOST)", fs.Lookup("host").Usage)
+	assert(t, "(default: app)", fs.Lookup("name").Usage)
+	assert(t, "Verbose output", fs.Lookup("verbose").Usage)
+}
+
+func TestFlagProvider_UsageHints_NotRegistered(t *testing.T) {
+	type testStruct struct {
+		Port int `flag:"port|8080|Port to listen" env:"APP_PORT"`
+	}
+	os.Args = []string{"smth"}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if _, err := New[testStruct](NewFlagProvider(WithFlagSet(fs))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, "Port to listen", fs.Lookup("port").Usage)
+}
@@ -0,0 +1,606 @@
+package configuration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// hclValue is a node of a parsed HCL document: a scalar, a list or an object (a body of a block).
+type hclValue struct {
+	line, col int
+	kind      hclKind
+
+	str  string      // hclScalar
+	list []*hclValue // hclList
+	obj  map[string]*hclValue
+	keys []string // keys of obj in order of declaration
+
+	block    bool // the object is a body of a block
+	labelled bool // the object groups blocks by their labels
+	blocks   bool // the list consists of repeated blocks
+}
+
+type hclKind int
+
+const (
+	hclScalar hclKind = iota
+	hclNull
+	hclList
+	hclObject
+)
+
+func (v *hclValue) pos() string {
+	return fmt.Sprintf("%d:%d", v.line, v.col)
+}
+
+func (v *hclValue) set(key string, child *hclValue) {
+	if _, ok := v.obj[key]; !ok {
+		v.keys = append(v.keys, key)
+	}
+	v.obj[key] = child
+}
+
+func newHCLObject(line, col int) *hclValue {
+	return &hclValue{line: line, col: col, kind: hclObject, obj: map[string]*hclValue{}}
+}
+
+type hclTokenKind int
+
+const (
+	hclEOF hclTokenKind = iota
+	hclNewline
+	hclIdent
+	hclString
+	hclNumber
+	hclPunct
+)
+
+type hclToken struct {
+	kind      hclTokenKind
+	text      string
+	line, col int
+}
+
+func (t hclToken) String() string {
+	switch t.kind {
+	case hclEOF:
+		return "end of file"
+	case hclNewline:
+		return "new line"
+	case hclString:
+		return strconv.Quote(t.text)
+	case hclIdent, hclNumber, hclPunct:
+	}
+
+	return "'" + t.text + "'"
+}
+
+// parseHCL parses a subset of HCL: attributes, (labelled) blocks, strings, heredocs, numbers, bools,
+// lists, objects and comments. Expressions (variables, functions, operators) aren't supported.
+func parseHCL(src []byte) (*hclValue, error) {
+	p := &hclParser{lexer: &hclLexer{src: src, line: 1, col: 1}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	body, err := p.parseBody(1, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != hclEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+
+	return body, nil
+}
+
+type hclParser struct {
+	lexer *hclLexer
+	tok   hclToken
+}
+
+func (p *hclParser) advance() (err error) {
+	p.tok, err = p.lexer.next()
+	return err
+}
+
+func (p *hclParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %d:%d: %s", ErrSyntax, p.tok.line, p.tok.col, fmt.Sprintf(format, args...))
+}
+
+func (p *hclParser) isPunct(s string) bool {
+	return p.tok.kind == hclPunct && p.tok.text == s
+}
+
+func (p *hclParser) skipNewlines() error {
+	for p.tok.kind == hclNewline {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *hclParser) parseBody(line, col int) (*hclValue, error) {
+	body := newHCLObject(line, col)
+	body.block = true
+
+	for {
+		if err := p.skipNewlines(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == hclEOF || p.isPunct("}") {
+			return body, nil
+		}
+
+		if p.tok.kind != hclIdent {
+			return nil, p.errorf("expected attribute or block name but got %s", p.tok)
+		}
+		name := p.tok
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.isPunct("=") {
+			if err := p.parseAttribute(body, name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := p.parseBlock(body, name); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *hclParser) parseAttribute(body *hclValue, name hclToken) error {
+	if _, ok := body.obj[name.text]; ok {
+		return fmt.Errorf("%w: %d:%d: duplicate attribute [%s]", ErrSyntax, name.line, name.col, name.text)
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	val, err := p.parseExpr()
+	if err != nil {
+		return err
+	}
+	body.set(name.text, val)
+
+	if p.tok.kind != hclNewline && p.tok.kind != hclEOF && !p.isPunct("}") {
+		return p.errorf("expected new line after attribute [%s] but got %s", name.text, p.tok)
+	}
+
+	return nil
+}
+
+func (p *hclParser) parseBlock(body *hclValue, name hclToken) error {
+	var labels []hclToken
+	for p.tok.kind == hclString || p.tok.kind == hclIdent {
+		labels = append(labels, p.tok)
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	if !p.isPunct("{") {
+		return p.errorf("expected '{' after block [%s] but got %s", name.text, p.tok)
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	block, err := p.parseBody(name.line, name.col)
+	if err != nil {
+		return err
+	}
+
+	if !p.isPunct("}") {
+		return p.errorf("block [%s] is not closed", name.text)
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	return addHCLBlock(body, name, labels, block)
+}
+
+// addHCLBlock puts labelled blocks into nested objects by their labels (`service "web" {}` -> service.web),
+// repeated blocks without labels are collected into a list.
+func addHCLBlock(body *hclValue, name hclToken, labels []hclToken, block *hclValue) error {
+	existing := body.obj[name.text]
+
+	if len(labels) == 0 {
+		switch {
+		case existing == nil:
+			body.set(name.text, block)
+		case existing.kind == hclList && existing.blocks:
+			existing.list = append(existing.list, block)
+		case existing.kind == hclObject && existing.block:
+			body.set(name.text, &hclValue{
+				line: existing.line, col: existing.col, kind: hclList, blocks: true,
+				list: []*hclValue{existing, block},
+			})
+		default:
+			return fmt.Errorf("%w: %d:%d: block [%s] conflicts with the one at %s",
+				ErrSyntax, name.line, name.col, name.text, existing.pos())
+		}
+
+		return nil
+	}
+
+	container := body
+	key := name
+	for _, label := range labels {
+		next := container.obj[key.text]
+		if next == nil {
+			next = newHCLObject(key.line, key.col)
+			next.labelled = true
+			container.set(key.text, next)
+		}
+
+		if !next.labelled {
+			return fmt.Errorf("%w: %d:%d: block [%s] conflicts with the one at %s",
+				ErrSyntax, name.line, name.col, name.text, next.pos())
+		}
+
+		container, key = next, label
+	}
+
+	if prev, ok := container.obj[key.text]; ok {
+		return fmt.Errorf("%w: %d:%d: duplicate block [%s %q], previous one is at %s",
+			ErrSyntax, name.line, name.col, name.text, key.text, prev.pos())
+	}
+	container.set(key.text, block)
+
+	return nil
+}
+
+func (p *hclParser) parseExpr() (*hclValue, error) {
+	tok := p.tok
+
+	switch {
+	case tok.kind == hclString || tok.kind == hclNumber:
+		return &hclValue{line: tok.line, col: tok.col, kind: hclScalar, str: tok.text}, p.advance()
+
+	case tok.kind == hclIdent && (tok.text == "true" || tok.text == "false"):
+		return &hclValue{line: tok.line, col: tok.col, kind: hclScalar, str: tok.text}, p.advance()
+
+	case tok.kind == hclIdent && tok.text == "null":
+		return &hclValue{line: tok.line, col: tok.col, kind: hclNull}, p.advance()
+
+	case p.isPunct("["):
+		return p.parseList()
+
+	case p.isPunct("{"):
+		return p.parseObject()
+	}
+
+	return nil, p.errorf("unsupported expression %s", tok)
+}
+
+func (p *hclParser) parseList() (*hclValue, error) {
+	list := &hclValue{line: p.tok.line, col: p.tok.col, kind: hclList}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	for {
+		if err := p.skipNewlines(); err != nil {
+			return nil, err
+		}
+
+		if p.isPunct("]") {
+			return list, p.advance()
+		}
+
+		item, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list.list = append(list.list, item)
+
+		if err := p.skipNewlines(); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.isPunct(","):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		case p.isPunct("]"):
+		default:
+			return nil, p.errorf("expected ',' or ']' but got %s", p.tok)
+		}
+	}
+}
+
+func (p *hclParser) parseObject() (*hclValue, error) {
+	obj := newHCLObject(p.tok.line, p.tok.col)
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	for {
+		if err := p.skipNewlines(); err != nil {
+			return nil, err
+		}
+
+		if p.isPunct("}") {
+			return obj, p.advance()
+		}
+
+		if p.tok.kind != hclIdent && p.tok.kind != hclString {
+			return nil, p.errorf("expected object key but got %s", p.tok)
+		}
+		key := p.tok
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if !p.isPunct("=") && !p.isPunct(":") {
+			return nil, p.errorf("expected '=' after object key [%s] but got %s", key.text, p.tok)
+		}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		val, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		obj.set(key.text, val)
+
+		if p.isPunct(",") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+type hclLexer struct {
+	src       []byte
+	pos       int
+	line, col int
+}
+
+func (l *hclLexer) errorf(line, col int, format string, args ...any) error {
+	return fmt.Errorf("%w: %d:%d: %s", ErrSyntax, line, col, fmt.Sprintf(format, args...))
+}
+
+func (l *hclLexer) peekByte(offset int) byte {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+
+	return 0
+}
+
+func (l *hclLexer) advanceBytes(n int) {
+	for range n {
+		switch {
+		case l.src[l.pos] == '\n':
+			l.line++
+			l.col = 1
+		case utf8.RuneStart(l.src[l.pos]): // continuation bytes of a multibyte rune don't move the column
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *hclLexer) next() (hclToken, error) {
+	if err := l.skipSpacesAndComments(); err != nil {
+		return hclToken{}, err
+	}
+
+	line, col := l.line, l.col
+	tok := hclToken{line: line, col: col}
+
+	if l.pos >= len(l.src) {
+		tok.kind = hclEOF
+		return tok, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '\n':
+		l.advanceBytes(1)
+		tok.kind, tok.text = hclNewline, "\n"
+
+	case c == '"':
+		s, err := l.readString()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind, tok.text = hclString, s
+
+	case c == '<' && l.peekByte(1) == '<':
+		s, err := l.readHeredoc()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind, tok.text = hclString, s
+
+	case isDigit(c) || (c == '-' && isDigit(l.peekByte(1))):
+		start := l.pos
+		l.advanceBytes(1)
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || strings.IndexByte(".eE+-", l.src[l.pos]) >= 0) {
+			l.advanceBytes(1)
+		}
+		tok.kind, tok.text = hclNumber, string(l.src[start:l.pos])
+
+	case strings.IndexByte("={}[],:", c) >= 0:
+		l.advanceBytes(1)
+		tok.kind, tok.text = hclPunct, string(c)
+
+	default:
+		r, size := utf8.DecodeRune(l.src[l.pos:])
+		if !unicode.IsLetter(r) && r != '_' {
+			return tok, l.errorf(line, col, "unexpected character %q", r)
+		}
+
+		start := l.pos
+		for l.pos < len(l.src) {
+			r, size = utf8.DecodeRune(l.src[l.pos:])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+				break
+			}
+			l.advanceBytes(size)
+		}
+		tok.kind, tok.text = hclIdent, string(l.src[start:l.pos])
+	}
+
+	return tok, nil
+}
+
+func (l *hclLexer) skipSpacesAndComments() error {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			l.advanceBytes(1)
+
+		case c == '#' || (c == '/' && l.peekByte(1) == '/'):
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advanceBytes(1)
+			}
+
+		case c == '/' && l.peekByte(1) == '*':
+			line, col := l.line, l.col
+			end := strings.Index(string(l.src[l.pos+2:]), "*/")
+			if end < 0 {
+				return l.errorf(line, col, "comment is not closed")
+			}
+			l.advanceBytes(end + 4) // nolint:mnd
+
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (l *hclLexer) readString() (string, error) {
+	line, col := l.line, l.col
+	start := l.pos
+	l.advanceBytes(1)
+
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return "", l.errorf(line, col, "string is not closed")
+			}
+			l.advanceBytes(2) // nolint:mnd
+
+		case '\n':
+			return "", l.errorf(line, col, "string is not closed")
+
+		case '"':
+			l.advanceBytes(1)
+
+			s, err := strconv.Unquote(string(l.src[start:l.pos]))
+			if err != nil {
+				return "", l.errorf(line, col, "invalid string: %v", err)
+			}
+
+			return s, nil
+
+		default:
+			l.advanceBytes(1)
+		}
+	}
+
+	return "", l.errorf(line, col, "string is not closed")
+}
+
+// readHeredoc reads `<<EOF` and `<<-EOF` (with stripped indentation) strings.
+func (l *hclLexer) readHeredoc() (string, error) {
+	line, col := l.line, l.col
+	l.advanceBytes(2) // nolint:mnd
+
+	indented := l.peekByte(0) == '-'
+	if indented {
+		l.advanceBytes(1)
+	}
+
+	eol := strings.IndexByte(string(l.src[l.pos:]), '\n')
+	if eol < 0 {
+		return "", l.errorf(line, col, "heredoc marker must be followed by a new line")
+	}
+
+	marker := strings.TrimSpace(string(l.src[l.pos : l.pos+eol]))
+	if len(marker) == 0 {
+		return "", l.errorf(line, col, "heredoc marker is empty")
+	}
+	l.advanceBytes(eol + 1)
+
+	var lines []string
+	for l.pos < len(l.src) {
+		eol = strings.IndexByte(string(l.src[l.pos:]), '\n')
+		if eol < 0 {
+			eol = len(l.src) - l.pos
+		}
+
+		text := string(l.src[l.pos : l.pos+eol])
+		if strings.TrimSpace(text) == marker {
+			l.advanceBytes(eol)
+			if indented {
+				lines = stripIndent(lines)
+			}
+
+			return strings.Join(lines, ""), nil
+		}
+
+		lines = append(lines, strings.TrimSuffix(text, "\r")+"\n")
+		l.advanceBytes(min(eol+1, len(l.src)-l.pos))
+	}
+
+	return "", l.errorf(line, col, "heredoc %s is not closed", marker)
+}
+
+func stripIndent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			lines[i] = line[indent:]
+		}
+	}
+
+	return lines
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
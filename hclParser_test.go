@@ -0,0 +1,105 @@
+package configuration
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseHCL_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"duplicate attribute": {
+			input:    "a = 1\na = 2",
+			expected: "syntax error: 2:1: duplicate attribute [a]",
+		},
+		"duplicate labelled block": {
+			input:    "s \"x\" {}\ns \"x\" {}",
+			expected: "syntax error: 2:1: duplicate block [s \"x\"], previous one is at 1:1",
+		},
+		"block conflicts with attribute": {
+			input:    "s = 1\ns {}",
+			expected: "syntax error: 2:1: block [s] conflicts with the one at 1:5",
+		},
+		"labelled block conflicts with block": {
+			input:    "s {}\ns \"x\" {}",
+			expected: "syntax error: 2:1: block [s] conflicts with the one at 1:1",
+		},
+		"not closed block": {
+			input:    "s {\n  a = 1\n",
+			expected: "syntax error: 3:1: block [s] is not closed",
+		},
+		"missing brace": {
+			input:    "s \"x\" = 1",
+			expected: "syntax error: 1:7: expected '{' after block [s] but got '='",
+		},
+		"two attributes in a line": {
+			input:    "a = 1 b = 2",
+			expected: "syntax error: 1:7: expected new line after attribute [a] but got 'b'",
+		},
+		"not closed string": {
+			input:    "a = \"value\nb = 1",
+			expected: "syntax error: 1:5: string is not closed",
+		},
+		"not closed comment": {
+			input:    "a = 1 /* comment",
+			expected: "syntax error: 1:7: comment is not closed",
+		},
+		"not closed heredoc": {
+			input:    "a = <<EOT\nline\n",
+			expected: "syntax error: 1:5: heredoc EOT is not closed",
+		},
+		"unexpected character": {
+			input:    "a = 1\n@",
+			expected: "syntax error: 2:1: unexpected character '@'",
+		},
+		"unexpected closing brace": {
+			input:    "}",
+			expected: "syntax error: 1:1: unexpected '}'",
+		},
+		"list without comma": {
+			input:    "a = [1 2]",
+			expected: "syntax error: 1:8: expected ',' or ']' but got '2'",
+		},
+		"object without =": {
+			input:    "a = { b 1 }",
+			expected: "syntax error: 1:9: expected '=' after object key [b] but got '1'",
+		},
+		"string as a name": {
+			input:    "\"a\" = 1",
+			expected: "syntax error: 1:1: expected attribute or block name but got \"a\"",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseHCL([]byte(test.input))
+			assert(t, test.expected, err.Error())
+			assert(t, true, errors.Is(err, ErrSyntax))
+		})
+	}
+}
+
+func TestParseHCL_NestedLabels(t *testing.T) {
+	t.Parallel()
+
+	root, err := parseHCL([]byte(`resource "aws" "web" { ami = "x" }` + "\n" + `resource "aws" "db" { ami = "y" }`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	web, ok := lookupHCL(root, []string{"resource", "aws", "web", "ami"})
+	assert(t, true, ok)
+	assert(t, "x", web.str)
+
+	db, ok := lookupHCL(root, []string{"resource", "aws", "db", "ami"})
+	assert(t, true, ok)
+	assert(t, "y", db.str)
+}
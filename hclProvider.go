@@ -0,0 +1,247 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	HCLFileProviderName = `HCLFileProvider`
+	HCLFileProviderTag  = `file_hcl`
+)
+
+var ErrHCLTypeMismatch = errors.New("type mismatch")
+
+// NewHCLFileProvider creates new provider which reads values from HCL files.
+// Attributes and blocks are addressed by `file_hcl:"server.port"` tag, labelled blocks are addressed
+// by their labels: `service "web" { port = 80 }` -> `file_hcl:"service.web.port"`.
+// Blocks may be decoded into maps or slices of structs, fields of such structs are addressed
+// by `file_hcl` tags relative to the block.
+func NewHCLFileProvider(fileName string) *HCLFileProvider {
+	return &HCLFileProvider{fileName: fileName}
+}
+
+type HCLFileProvider struct {
+	fileName string
+	root     *hclValue
+}
+
+func (*HCLFileProvider) Name() string {
+	return HCLFileProviderName
+}
+
+func (*HCLFileProvider) Tag() string {
+	return HCLFileProviderTag
+}
+
+func (fp *HCLFileProvider) Init(_ any) error {
+	b, err := os.ReadFile(fp.fileName)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w", HCLFileProviderName, err)
+	}
+
+	if fp.root, err = parseHCL(b); err != nil {
+		return fmt.Errorf("%s.Init: %s: %w", HCLFileProviderName, fp.fileName, err)
+	}
+
+	return nil
+}
+
+func (fp *HCLFileProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	path := field.Tag.Get(HCLFileProviderTag)
+	if len(path) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", HCLFileProviderName)
+	}
+
+	node, ok := lookupHCL(fp.root, strings.Split(path, "."))
+	if !ok {
+		return fmt.Errorf("%s: %w", HCLFileProviderName, ErrEmptyValue)
+	}
+
+	if err := decodeHCL(node, field, v); err != nil {
+		return fmt.Errorf("%s: %s: %w", HCLFileProviderName, fp.fileName, err)
+	}
+
+	return nil
+}
+
+func lookupHCL(node *hclValue, path []string) (*hclValue, bool) {
+	for _, key := range path {
+		switch {
+		case node == nil:
+			return nil, false
+
+		case node.kind == hclObject:
+			node = node.obj[key]
+
+		case node.kind == hclList:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node.list) {
+				return nil, false
+			}
+			node = node.list[i]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return node, node != nil && node.kind != hclNull
+}
+
+// decodeHCL sets the node into the field: scalars and lists of scalars are set by SetField,
+// blocks are decoded into structs, maps and slices of structs.
+// nolint:cyclop
+func decodeHCL(node *hclValue, field reflect.StructField, v reflect.Value) error {
+	t := field.Type
+
+	switch {
+	case isStructType(t):
+		if node.kind != hclObject {
+			return fmt.Errorf("%s: %w: [%s] expects a block or an object", node.pos(), ErrHCLTypeMismatch, field.Name)
+		}
+
+		return decodeHCLStruct(node, v)
+
+	case t.Kind() == reflect.Map:
+		if node.kind != hclObject {
+			return fmt.Errorf("%s: %w: [%s] expects a block or an object", node.pos(), ErrHCLTypeMismatch, field.Name)
+		}
+
+		if t.Key().Kind() != reflect.String {
+			return fmt.Errorf("%s: %w: [%s] must have string keys", node.pos(), ErrHCLTypeMismatch, field.Name)
+		}
+
+		m := reflect.MakeMapWithSize(t, len(node.keys))
+		for _, key := range node.keys {
+			if node.obj[key].kind == hclNull {
+				continue
+			}
+
+			elem := reflect.New(t.Elem()).Elem()
+			if err := decodeHCL(node.obj[key], reflect.StructField{Name: field.Name, Type: t.Elem()}, elem); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elem)
+		}
+		v.Set(m)
+
+		return nil
+
+	case t.Kind() == reflect.Slice && isStructType(t.Elem()):
+		items := node.list
+		switch {
+		case node.kind == hclObject && node.labelled:
+			for _, key := range node.keys {
+				items = append(items, node.obj[key])
+			}
+		case node.kind == hclObject:
+			items = []*hclValue{node}
+		case node.kind != hclList:
+			return fmt.Errorf("%s: %w: [%s] expects blocks or a list", node.pos(), ErrHCLTypeMismatch, field.Name)
+		}
+
+		slice := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := decodeHCL(item, reflect.StructField{Name: field.Name, Type: t.Elem()}, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+
+		return nil
+	}
+
+	valStr, err := hclScalarString(node, field)
+	if err != nil {
+		return err
+	}
+
+	if err := SetField(field, v, valStr); err != nil {
+		return fmt.Errorf("%s: %w", node.pos(), err)
+	}
+
+	return nil
+}
+
+func hclScalarString(node *hclValue, field reflect.StructField) (string, error) {
+	switch node.kind {
+	case hclScalar:
+		return node.str, nil
+
+	case hclList:
+		items := make([]string, 0, len(node.list))
+		for _, item := range node.list {
+			if item.kind != hclScalar {
+				return "", fmt.Errorf("%s: %w: [%s] expects a list of values", item.pos(), ErrHCLTypeMismatch, field.Name)
+			}
+			items = append(items, item.str)
+		}
+
+		return strings.Join(items, sliceSeparator), nil
+
+	case hclNull, hclObject:
+	}
+
+	return "", fmt.Errorf("%s: %w: [%s] expects a value but got a block or an object", node.pos(), ErrHCLTypeMismatch, field.Name)
+}
+
+// decodeHCLStruct sets fields of the struct from the block using relative `file_hcl` tags.
+// Missing attributes are set from `default` tag if it's present.
+func decodeHCLStruct(node *hclValue, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := field.Tag.Get(HCLFileProviderTag)
+		if len(path) == 0 {
+			if isStructType(field.Type) {
+				if err := decodeHCLStruct(node, v.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		child, ok := lookupHCL(node, strings.Split(path, "."))
+		if !ok {
+			if def := field.Tag.Get(DefaultProviderTag); len(def) > 0 {
+				if err := SetField(field, v.Field(i), def); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := decodeHCL(child, field, v.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isStructType reports whether t is a struct or a pointer to struct without its own FieldSetter.
+func isStructType(t reflect.Type) bool {
+	if isFieldSetter(t) {
+		return false
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
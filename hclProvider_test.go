@@ -0,0 +1,144 @@
+package configuration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHCLFileProvider(t *testing.T) {
+	t.Parallel()
+
+	type service struct {
+		Port int    `file_hcl:"port"`
+		Motd string `file_hcl:"motd" default:"none"`
+	}
+
+	type cfg struct {
+		Name    string        `file_hcl:"name"`
+		Timeout time.Duration `file_hcl:"timeout"`
+		Ratio   float64       `file_hcl:"ratio"`
+		Tags    []string      `file_hcl:"tags"`
+		Server  struct {
+			Host string `file_hcl:"server.host"`
+			Port int    `file_hcl:"server.port"`
+			TLS  bool   `file_hcl:"server.tls"`
+		}
+		WebPort  int                `file_hcl:"service.web.port"`
+		Services map[string]service `file_hcl:"service"`
+		List     []service          `file_hcl:"service"`
+		Rules    []*struct {
+			N string `file_hcl:"name"`
+		} `file_hcl:"rule"`
+		Second string         `file_hcl:"rule.1.name"`
+		Limits map[string]int `file_hcl:"limits"`
+	}
+
+	got, err := New[cfg](NewHCLFileProvider("./testdata/input.hcl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, "test_name_hcl", got.Name)
+	assert(t, 101*time.Millisecond, got.Timeout)
+	assert(t, -150.0, got.Ratio)
+	assert(t, []string{"a", "b", "c"}, got.Tags)
+	assert(t, "localhost", got.Server.Host)
+	assert(t, 8080, got.Server.Port)
+	assert(t, true, got.Server.TLS)
+	assert(t, 80, got.WebPort)
+	assert(t, map[string]service{
+		"web": {Port: 80, Motd: "hello\n  world\n"},
+		"api": {Port: 8081, Motd: "none"},
+	}, got.Services)
+	assert(t, []service{{Port: 80, Motd: "hello\n  world\n"}, {Port: 8081, Motd: "none"}}, got.List)
+	assert(t, 2, len(got.Rules))
+	assert(t, "first", got.Rules[0].N)
+	assert(t, "second", got.Rules[1].N)
+	assert(t, "second", got.Second)
+	assert(t, map[string]int{"cpu": 2, "mem": 512}, got.Limits)
+}
+
+func TestHCLFileProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		obj      any
+		expected string
+	}{
+		"block into a value": {
+			obj: &struct {
+				Server string `file_hcl:"server"`
+			}{},
+			expected: "HCLFileProvider: ./testdata/input.hcl: 11:1: type mismatch: [Server] expects a value but got a block or an object",
+		},
+		"value into a map": {
+			obj: &struct {
+				Name map[string]string `file_hcl:"name"`
+			}{},
+			expected: "HCLFileProvider: ./testdata/input.hcl: 2:11: type mismatch: [Name] expects a block or an object",
+		},
+		"value into a slice of structs": {
+			obj: &struct {
+				Name []struct{} `file_hcl:"name"`
+			}{},
+			expected: "HCLFileProvider: ./testdata/input.hcl: 2:11: type mismatch: [Name] expects blocks or a list",
+		},
+		"block into a struct field": {
+			obj: &struct {
+				Services map[string]struct {
+					Port []struct{} `file_hcl:"port"`
+				} `file_hcl:"service"`
+			}{},
+			expected: "HCLFileProvider: ./testdata/input.hcl: 18:10: type mismatch: [Port] expects blocks or a list",
+		},
+	}
+
+	p := NewHCLFileProvider("./testdata/input.hcl")
+	if err := p.Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fieldType := reflect.TypeOf(test.obj).Elem().Field(0)
+			fieldVal := reflect.ValueOf(test.obj).Elem().Field(0)
+
+			err := p.Provide(fieldType, fieldVal)
+			assert(t, test.expected, err.Error())
+			assert(t, true, errors.Is(err, ErrHCLTypeMismatch))
+		})
+	}
+}
+
+func TestHCLFileProvider_NotFound(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Missing string `file_hcl:"server.missing" default:"fallback"`
+		Null    string `file_hcl:"tags.5"         default:"out of range"`
+		Empty   string `file_hcl:""               default:"no key"`
+	}
+
+	got, err := New[cfg](NewHCLFileProvider("./testdata/input.hcl"), NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &cfg{Missing: "fallback", Null: "out of range", Empty: "no key"}, got)
+}
+
+func TestHCLFileProvider_Init(t *testing.T) {
+	t.Parallel()
+
+	err := NewHCLFileProvider("./testdata/malformed_input.hcl").Init(nil)
+	assert(t, "HCLFileProvider.Init: ./testdata/malformed_input.hcl: syntax error: 3:10: unsupported expression 'localhost'", err.Error())
+
+	err = NewHCLFileProvider("doesn't exist").Init(nil)
+	assert(t, "HCLFileProvider.Init: open doesn't exist: no such file or directory", err.Error())
+}
@@ -0,0 +1,283 @@
+package configuration
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	HTTPProviderName = `HTTPProvider`
+	HTTPProviderTag  = `http`
+)
+
+var ErrUnexpectedStatus = errors.New("unexpected status")
+
+type HTTPProviderOption func(*HTTPProvider)
+
+// WithHTTPTimeout sets the timeout of every request (10s by default).
+func WithHTTPTimeout(timeout time.Duration) HTTPProviderOption {
+	return func(hp *HTTPProvider) {
+		hp.timeout = timeout
+	}
+}
+
+// WithHTTPRetries retries failed requests: network errors, 5xx and 429 responses.
+// The delay before the first retry is backoff, it's doubled for every next one.
+func WithHTTPRetries(retries int, backoff time.Duration) HTTPProviderOption {
+	return func(hp *HTTPProvider) {
+		hp.retries = retries
+		hp.backoff = backoff
+	}
+}
+
+// WithHTTPHeader adds the header to requests, e.g. `Authorization`.
+func WithHTTPHeader(key, value string) HTTPProviderOption {
+	return func(hp *HTTPProvider) {
+		hp.headers.Add(key, value)
+	}
+}
+
+// WithHTTPTLSConfig sets TLS options: custom CAs, client certificates, etc.
+func WithHTTPTLSConfig(cfg *tls.Config) HTTPProviderOption {
+	return func(hp *HTTPProvider) {
+		hp.tlsConfig = cfg
+	}
+}
+
+// WithHTTPClient sets the client which is used instead of the default one (WithHTTPTLSConfig is ignored then).
+func WithHTTPClient(client *http.Client) HTTPProviderOption {
+	return func(hp *HTTPProvider) {
+		hp.client = client
+	}
+}
+
+// WithHTTPCacheFile stores the last fetched document and its ETag in the file.
+// The ETag is sent in `If-None-Match`, the cached document is used on `304 Not Modified`
+// and if the endpoint isn't available (network errors, 5xx and 429 responses), but not on other statuses.
+// Only documents which have been decoded successfully are cached.
+func WithHTTPCacheFile(fileName string) HTTPProviderOption {
+	return func(hp *HTTPProvider) {
+		hp.cacheFile = fileName
+	}
+}
+
+// WithHTTPDecoder sets the decoder of the document (JSON by default), e.g. for YAML.
+// It must return a tree of map[string]any, []any and scalars.
+func WithHTTPDecoder(decode func([]byte) (any, error)) HTTPProviderOption {
+	return func(hp *HTTPProvider) {
+		hp.decode = decode
+	}
+}
+
+// NewHTTPProvider creates new provider which fetches the document from the url during Init.
+// Values are addressed by `http:"db.host"` tag the same way as in JSON files.
+func NewHTTPProvider(url string, opts ...HTTPProviderOption) *HTTPProvider {
+	hp := &HTTPProvider{
+		url:     url,
+		headers: http.Header{},
+		timeout: defaultTimeout,
+		decode: func(b []byte) (doc any, err error) {
+			return doc, json.Unmarshal(b, &doc)
+		},
+	}
+
+	for _, f := range opts {
+		f(hp)
+	}
+
+	return hp
+}
+
+type HTTPProvider struct {
+	url       string
+	headers   http.Header
+	timeout   time.Duration
+	retries   int
+	backoff   time.Duration
+	tlsConfig *tls.Config
+	client    *http.Client
+	cacheFile string
+	decode    func([]byte) (any, error)
+	fileData  any
+}
+
+type httpCache struct {
+	ETag string `json:"etag"`
+	Body []byte `json:"body"`
+}
+
+func (*HTTPProvider) Name() string {
+	return HTTPProviderName
+}
+
+func (*HTTPProvider) Tag() string {
+	return HTTPProviderTag
+}
+
+func (hp *HTTPProvider) Init(_ any) error {
+	cached := hp.readCache()
+
+	doc, unavailable, err := hp.fetch(cached)
+	if err != nil {
+		// the cache isn't used on client errors (e.g. 401 or 404), they must be fixed instead
+		if cached == nil || !unavailable {
+			return fmt.Errorf("%s.Init: %w", HTTPProviderName, err)
+		}
+		doc = cached
+	}
+
+	if hp.fileData, err = hp.decode(doc.Body); err != nil {
+		return fmt.Errorf("%s.Init: %w", HTTPProviderName, err)
+	}
+
+	// only documents which have been decoded are cached, so a broken response doesn't replace a good cache
+	if doc != cached {
+		hp.writeCache(doc)
+	}
+
+	return nil
+}
+
+func (hp *HTTPProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	path := field.Tag.Get(HTTPProviderTag)
+	if len(path) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", HTTPProviderName)
+	}
+
+	valStr, ok := findValStrByPath(hp.fileData, strings.Split(path, "."))
+	if !ok {
+		return fmt.Errorf("%s: findValStrByPath returns empty value", HTTPProviderName)
+	}
+
+	return SetField(field, v, valStr)
+}
+
+// fetch returns the document (which is the cached one on `304 Not Modified`)
+// and whether the endpoint is unavailable if the request failed.
+func (hp *HTTPProvider) fetch(cached *httpCache) (*httpCache, bool, error) {
+	client := hp.client
+	if client == nil {
+		client = &http.Client{}
+		if hp.tlsConfig != nil {
+			client.Transport = &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: hp.tlsConfig,
+			}
+		}
+	}
+
+	var (
+		doc   *httpCache
+		retry bool
+		err   error
+	)
+	for attempt := 0; attempt <= hp.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(hp.backoff << (attempt - 1))
+		}
+
+		if doc, retry, err = hp.fetchOnce(client, cached); err == nil || !retry {
+			break
+		}
+	}
+
+	return doc, retry, err
+}
+
+// fetchOnce returns the document and whether the request may be retried if it failed.
+func (hp *HTTPProvider) fetchOnce(client *http.Client, cached *httpCache) (*httpCache, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), hp.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hp.url, nil)
+	if err != nil {
+		return nil, false, err // nolint:wrapcheck
+	}
+	req.Header = hp.headers.Clone()
+
+	if cached != nil && len(cached.ETag) > 0 {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, true, err // nolint:wrapcheck
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return cached, false, nil
+
+	case resp.StatusCode == http.StatusOK:
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, true, err // nolint:wrapcheck
+		}
+
+		return &httpCache{ETag: resp.Header.Get("ETag"), Body: b}, false, nil
+	}
+
+	retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+
+	return nil, retry, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+}
+
+// readCache returns nil if there is no cache file or it can't be read.
+func (hp *HTTPProvider) readCache() *httpCache {
+	if len(hp.cacheFile) == 0 {
+		return nil
+	}
+
+	b, err := os.ReadFile(hp.cacheFile)
+	if err != nil {
+		return nil
+	}
+
+	var cached httpCache
+	if err := json.Unmarshal(b, &cached); err != nil {
+		return nil
+	}
+
+	return &cached
+}
+
+// writeCache replaces the cache file atomically. Errors are ignored: the cache is only a fallback.
+func (hp *HTTPProvider) writeCache(doc *httpCache) {
+	if len(hp.cacheFile) == 0 {
+		return
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(hp.cacheFile), filepath.Base(hp.cacheFile)+".*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	_ = os.Rename(tmp.Name(), hp.cacheFile)
+}
@@ -0,0 +1,231 @@
+package configuration
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type _httpCfg struct {
+	Host  string   `http:"db.host"`
+	Port  int      `http:"db.port"`
+	Peers []string `http:"peers"`
+}
+
+const _httpDoc = `{"db": {"host": "db.internal", "port": 5432}, "peers": ["a", "b"]}`
+
+func TestHTTPProvider(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(_httpDoc))
+	}))
+	defer srv.Close()
+
+	got, err := New[_httpCfg](NewHTTPProvider(srv.URL, WithHTTPHeader("Authorization", "Bearer token")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &_httpCfg{Host: "db.internal", Port: 5432, Peers: []string{"a", "b"}}, got)
+
+	err = NewHTTPProvider(srv.URL, WithHTTPRetries(3, time.Millisecond)).Init(nil)
+	assert(t, "HTTPProvider.Init: unexpected status: 401 Unauthorized", err.Error())
+}
+
+func TestHTTPProvider_Retries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(_httpDoc))
+	}))
+	defer srv.Close()
+
+	err := NewHTTPProvider(srv.URL, WithHTTPRetries(1, time.Millisecond)).Init(nil)
+	assert(t, true, errors.Is(err, ErrUnexpectedStatus))
+	assert(t, int32(2), calls.Load())
+
+	if err := NewHTTPProvider(srv.URL, WithHTTPRetries(1, time.Millisecond)).Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, int32(3), calls.Load())
+}
+
+func TestHTTPProvider_Timeout(t *testing.T) {
+	t.Parallel()
+
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	err := NewHTTPProvider(srv.URL, WithHTTPTimeout(10*time.Millisecond)).Init(nil)
+	assert(t, true, strings.Contains(err.Error(), "context deadline exceeded"))
+}
+
+func TestHTTPProvider_Cache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls       atomic.Int32
+		unavailable atomic.Bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		switch {
+		case unavailable.Load():
+			w.WriteHeader(http.StatusBadGateway)
+		case r.Header.Get("If-None-Match") == `"v1"`:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte(_httpDoc))
+		}
+	}))
+	defer srv.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "config.cache")
+	expected := &_httpCfg{Host: "db.internal", Port: 5432, Peers: []string{"a", "b"}}
+
+	for range 2 { // the first request fills the cache, the second one gets 304
+		got, err := New[_httpCfg](NewHTTPProvider(srv.URL, WithHTTPCacheFile(cacheFile)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assert(t, expected, got)
+	}
+
+	unavailable.Store(true)
+
+	got, err := New[_httpCfg](NewHTTPProvider(srv.URL, WithHTTPCacheFile(cacheFile)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, expected, got)
+	assert(t, int32(3), calls.Load())
+
+	err = NewHTTPProvider(srv.URL, WithHTTPCacheFile(filepath.Join(t.TempDir(), "nope"))).Init(nil)
+	assert(t, "HTTPProvider.Init: unexpected status: 502 Bad Gateway", err.Error())
+}
+
+func TestHTTPProvider_CacheIsNotReplaced(t *testing.T) {
+	t.Parallel()
+
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if status.Load() != http.StatusOK {
+			w.WriteHeader(int(status.Load()))
+			return
+		}
+		_, _ = w.Write([]byte(_httpDoc))
+	}))
+	defer srv.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "config.cache")
+	if err := NewHTTPProvider(srv.URL, WithHTTPCacheFile(cacheFile)).Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the malformed document is returned with 200, so it fails the decoding and isn't cached
+	malformed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"db": `))
+	}))
+	defer malformed.Close()
+
+	err := NewHTTPProvider(malformed.URL, WithHTTPCacheFile(cacheFile)).Init(nil)
+	assert(t, true, err != nil)
+
+	status.Store(http.StatusServiceUnavailable)
+
+	got, err := New[_httpCfg](NewHTTPProvider(srv.URL, WithHTTPCacheFile(cacheFile)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &_httpCfg{Host: "db.internal", Port: 5432, Peers: []string{"a", "b"}}, got)
+
+	// client errors aren't hidden by the cache
+	status.Store(http.StatusUnauthorized)
+
+	err = NewHTTPProvider(srv.URL, WithHTTPCacheFile(cacheFile)).Init(nil)
+	assert(t, "HTTPProvider.Init: unexpected status: 401 Unauthorized", err.Error())
+}
+
+func TestHTTPProvider_TLS(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(_httpDoc))
+	}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // handshake errors of the untrusted client
+	srv.StartTLS()
+	defer srv.Close()
+
+	assert(t, true, NewHTTPProvider(srv.URL).Init(nil) != nil) // unknown authority
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	got, err := New[_httpCfg](NewHTTPProvider(srv.URL, WithHTTPTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "db.internal", got.Host)
+
+	got, err = New[_httpCfg](NewHTTPProvider(srv.URL, WithHTTPClient(srv.Client())))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, 5432, got.Port)
+}
+
+func TestHTTPProvider_Decoder(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("db.host=db.internal\ndb.port=5432\npeers=a;b"))
+	}))
+	defer srv.Close()
+
+	decode := func(b []byte) (any, error) {
+		values, err := parseProperties(strings.NewReader(string(b)))
+		if err != nil {
+			return nil, err
+		}
+
+		return nestKeys(values, func(val string) any { return val })
+	}
+
+	got, err := New[_httpCfg](NewHTTPProvider(srv.URL, WithHTTPDecoder(decode)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &_httpCfg{Host: "db.internal", Port: 5432, Peers: []string{"a", "b"}}, got)
+
+	err = NewHTTPProvider(srv.URL).Init(nil)
+	assert(t, "HTTPProvider.Init: invalid character 'd' looking for beginning of value", err.Error())
+}
@@ -0,0 +1,137 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// IncludeKey is the key of the include directive: `"$include": "db.json"` or `"$include": ["a.json", "b.json"]`.
+	IncludeKey = `$include`
+
+	defaultMaxIncludeDepth = 8
+)
+
+var (
+	ErrIncludeCycle = errors.New("include cycle")
+	ErrIncludeDepth = errors.New("include depth limit exceeded")
+)
+
+// includeResolver replaces include directives by the content of included files.
+type includeResolver struct {
+	readFile  func(name string) ([]byte, error)
+	resolve   func(base, name string) string
+	unmarshal func([]byte, any) error
+	maxDepth  int
+	stack     []string
+}
+
+// resolveIncludes resolves include directives of doc which was read from the file base.
+// Included documents are deep-merged in order of declaration, keys of the including object override them.
+// Paths are relative to the including file.
+func (r *includeResolver) resolveIncludes(doc any, base string) (any, error) {
+	switch doc := doc.(type) {
+	case map[string]any:
+		names, err := includeNames(doc[IncludeKey])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", base, err)
+		}
+		delete(doc, IncludeKey)
+
+		var merged any
+		for _, name := range names {
+			included, err := r.include(r.resolve(base, name))
+			if err != nil {
+				return nil, err
+			}
+			merged = mergeDocuments(merged, included, ArrayReplace, "", "", map[string]string{})
+		}
+
+		for k, v := range doc {
+			if doc[k], err = r.resolveIncludes(v, base); err != nil {
+				return nil, err
+			}
+		}
+
+		if merged == nil {
+			return doc, nil
+		}
+
+		return mergeDocuments(merged, doc, ArrayReplace, "", "", map[string]string{}), nil
+
+	case []any:
+		for i, v := range doc {
+			var err error
+			if doc[i], err = r.resolveIncludes(v, base); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+func (r *includeResolver) include(name string) (any, error) {
+	if slices.Contains(r.stack, name) {
+		return nil, fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(append(r.stack, name), " -> "))
+	}
+
+	if len(r.stack) > r.maxDepth {
+		return nil, fmt.Errorf("%w: %s", ErrIncludeDepth, name)
+	}
+
+	b, err := r.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := r.unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	r.stack = append(r.stack, name)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	return r.resolveIncludes(doc, name)
+}
+
+func includeNames(val any) ([]string, error) {
+	switch val := val.(type) {
+	case nil:
+		return nil, nil
+
+	case string:
+		return []string{val}, nil
+
+	case []any:
+		names := make([]string, 0, len(val))
+		for _, item := range val {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s must be a string or a list of strings", ErrSyntax, IncludeKey)
+			}
+			names = append(names, name)
+		}
+
+		return names, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s must be a string or a list of strings", ErrSyntax, IncludeKey)
+}
+
+func resolveOSInclude(base, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+
+	return filepath.Join(filepath.Dir(base), name)
+}
+
+func resolveFSInclude(base, name string) string {
+	return path.Join(path.Dir(base), name)
+}
@@ -0,0 +1,90 @@
+package configuration
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type _includeCfg struct {
+	Name     string        `file_json:"name"`
+	Timeout  time.Duration `file_json:"timeout"`
+	DBHost   string        `file_json:"db.host"`
+	DBName   string        `file_json:"db.name"`
+	PoolSize int           `file_json:"db.pool.size"`
+}
+
+func TestJSONFileProvider_Includes(t *testing.T) {
+	t.Parallel()
+
+	got, err := New[_includeCfg](NewJSONFileProvider("./testdata/include/service.json", WithIncludes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &_includeCfg{
+		Name:     "billing",
+		Timeout:  5 * time.Second,
+		DBHost:   "db.internal",
+		DBName:   "billing",
+		PoolSize: 10,
+	}, got)
+}
+
+func TestJSONFileProvider_IncludesDisabled(t *testing.T) {
+	t.Parallel()
+
+	p := NewJSONFileProvider("./testdata/include/service.json")
+	if err := p.Init(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	val, _ := findValStrByPath(p.fileData, []string{IncludeKey})
+	assert(t, "shared/defaults.json;shared/db.json", val)
+}
+
+func TestJSONFileProvider_IncludesFromFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"conf/app.json":     {Data: []byte(`{"$include": "../common/db.json", "db": {"port": 5433}}`)},
+		"common/db.json":    {Data: []byte(`{"db": {"host": "db.internal", "port": 5432}}`)},
+		"common/cycle.json": {Data: []byte(`{"$include": "cycle.json"}`)},
+	}
+
+	type cfg struct {
+		Host string `file_json:"db.host"`
+		Port int    `file_json:"db.port"`
+	}
+
+	got, err := New[cfg](NewJSONFileProviderFromFS(fsys, "conf/app.json", WithIncludes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db.internal", Port: 5433}, got)
+
+	err = NewJSONFileProviderFromFS(fsys, "common/cycle.json", WithIncludes()).Init(nil)
+	assert(t, "JSONFileProvider.Init: include cycle: common/cycle.json -> common/cycle.json", err.Error())
+}
+
+func TestJSONFileProvider_IncludeErrors(t *testing.T) {
+	t.Parallel()
+
+	err := NewJSONFileProvider("./testdata/include/cycle_a.json", WithIncludes()).Init(nil)
+	assert(t, true, errors.Is(err, ErrIncludeCycle))
+	assert(t, "JSONFileProvider.Init: include cycle: "+
+		filepath.Join("testdata", "include", "cycle_a.json")+" -> "+
+		filepath.Join("testdata", "include", "cycle_b.json")+" -> "+
+		filepath.Join("testdata", "include", "cycle_a.json"), err.Error())
+
+	err = NewJSONFileProvider("./testdata/include/service.json", WithMaxIncludeDepth(1)).Init(nil)
+	assert(t, true, errors.Is(err, ErrIncludeDepth))
+
+	err = NewJSONFileProviderFromBytes([]byte(`{"$include": 1}`), WithIncludes()).Init(nil)
+	assert(t, "JSONFileProvider.Init: .: syntax error: $include must be a string or a list of strings", err.Error())
+
+	err = NewJSONFileProviderFromBytes([]byte(`{"a": {"$include": "missing.json"}}`), WithIncludes()).Init(nil)
+	assert(t, "JSONFileProvider.Init: open missing.json: no such file or directory", err.Error())
+}
@@ -0,0 +1,201 @@
+package configuration
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	INIFileProviderName = `INIFileProvider`
+	INIFileProviderTag  = `file_ini`
+)
+
+type INIFileProviderOption func(*INIFileProvider)
+
+// WithINICaseSensitive makes section and key names case-sensitive. They are case-insensitive by default.
+func WithINICaseSensitive() INIFileProviderOption {
+	return func(fp *INIFileProvider) {
+		fp.caseSensitive = true
+	}
+}
+
+// NewINIFileProvider creates new provider which reads values from INI files.
+// Values are addressed by `file_ini:"section.key"` tag (or `file_ini:"key"` for keys before the first section).
+// Duplicate keys are collected into a list for slice fields, the last one wins for other fields.
+func NewINIFileProvider(fileName string, opts ...INIFileProviderOption) *INIFileProvider {
+	fp := &INIFileProvider{fileName: fileName}
+
+	for _, f := range opts {
+		f(fp)
+	}
+
+	return fp
+}
+
+type INIFileProvider struct {
+	fileName      string
+	caseSensitive bool
+	values        map[string][]string
+}
+
+func (*INIFileProvider) Name() string {
+	return INIFileProviderName
+}
+
+func (*INIFileProvider) Tag() string {
+	return INIFileProviderTag
+}
+
+func (fp *INIFileProvider) Init(_ any) error {
+	file, err := os.Open(fp.fileName)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w", INIFileProviderName, err)
+	}
+	defer file.Close()
+
+	if fp.values, err = parseINI(file, fp.caseSensitive); err != nil {
+		return fmt.Errorf("%s.Init: %w", INIFileProviderName, err)
+	}
+
+	return nil
+}
+
+func (fp *INIFileProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	path := field.Tag.Get(INIFileProviderTag)
+	if len(path) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", INIFileProviderName)
+	}
+
+	if !fp.caseSensitive {
+		path = strings.ToLower(path)
+	}
+
+	values := fp.values[path]
+	if len(values) == 0 {
+		return fmt.Errorf("%s: %w", INIFileProviderName, ErrEmptyValue)
+	}
+
+	valStr := values[len(values)-1]
+	if isRepeatable(field.Type) {
+		valStr = strings.Join(values, sliceSeparator)
+	}
+
+	if len(valStr) == 0 {
+		return fmt.Errorf("%s: %w", INIFileProviderName, ErrEmptyValue)
+	}
+
+	return SetField(field, v, valStr)
+}
+
+// parseINI returns values of all keys addressed as `section.key`.
+func parseINI(r io.Reader, caseSensitive bool) (map[string][]string, error) {
+	var (
+		values  = map[string][]string{}
+		section string
+		lineNum int
+		scanner = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case len(line) == 0 || line[0] == ';' || line[0] == '#':
+			continue
+
+		case line[0] == '[':
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: line %d: section is not closed", ErrSyntax, lineNum)
+			}
+			section = strings.TrimSpace(line[1:end])
+			continue
+		}
+
+		key, rawVal, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: missing '='", ErrSyntax, lineNum)
+		}
+
+		key = strings.TrimSpace(key)
+		if len(key) == 0 {
+			return nil, fmt.Errorf("%w: line %d: key is empty", ErrSyntax, lineNum)
+		}
+
+		val, err := parseINIValue(strings.TrimSpace(rawVal))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrSyntax, lineNum, err)
+		}
+
+		if len(section) > 0 {
+			key = section + "." + key
+		}
+
+		if !caseSensitive {
+			key = strings.ToLower(key)
+		}
+
+		values[key] = append(values[key], val)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	return values, nil
+}
+
+// parseINIValue unquotes the value and strips an inline comment (`value ; comment`).
+func parseINIValue(raw string) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	var val, rest string
+
+	switch raw[0] {
+	case '"':
+		quoted, err := strconv.QuotedPrefix(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value %s", raw)
+		}
+
+		if val, err = strconv.Unquote(quoted); err != nil {
+			return "", err // nolint:wrapcheck
+		}
+		rest = raw[len(quoted):]
+
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("invalid quoted value %s", raw)
+		}
+		val, rest = raw[1:end+1], raw[end+2:]
+
+	default:
+		return stripINIComment(raw), nil
+	}
+
+	if rest = strings.TrimSpace(rest); len(rest) > 0 && rest[0] != ';' && rest[0] != '#' {
+		return "", fmt.Errorf("unexpected characters after quoted value: %s", rest)
+	}
+
+	return val, nil
+}
+
+func stripINIComment(raw string) string {
+	for i := 1; i < len(raw); i++ {
+		if (raw[i] == ';' || raw[i] == '#') && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			return strings.TrimSpace(raw[:i])
+		}
+	}
+
+	return raw
+}
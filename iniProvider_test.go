@@ -0,0 +1,123 @@
+package configuration
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestINIFileProvider(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Name    string        `file_ini:"name"`
+		Timeout time.Duration `file_ini:"timeout"`
+		Server  struct {
+			Host  string   `file_ini:"server.host"`
+			Port  int      `file_ini:"server.port"`
+			Motd  string   `file_ini:"server.motd"`
+			Peers []string `file_ini:"server.peer"`
+			Peer  string   `file_ini:"server.peer"`
+			TLS   bool     `file_ini:"server.tls.enabled"`
+		}
+	}
+
+	got, err := New[cfg](NewINIFileProvider("./testdata/input.ini"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, "test_name_ini", got.Name)
+	assert(t, 101*time.Millisecond, got.Timeout)
+	assert(t, "127.0.0.1", got.Server.Host)
+	assert(t, 8080, got.Server.Port)
+	assert(t, "a;b #not a comment", got.Server.Motd)
+	assert(t, []string{"a", "b", "c"}, got.Server.Peers)
+	assert(t, "c", got.Server.Peer)
+	assert(t, true, got.Server.TLS)
+}
+
+func TestINIFileProvider_CaseSensitive(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Timeout string   `file_ini:"Timeout"`
+		Peers   []string `file_ini:"server.peer"`
+		Name    string   `file_ini:"NAME"     default:"not found"`
+	}
+
+	got, err := New[cfg](NewINIFileProvider("./testdata/input.ini", WithINICaseSensitive()), NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &cfg{Timeout: "101ms", Peers: []string{"a", "b"}, Name: "not found"}, got)
+}
+
+func TestParseINI_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"section is not closed": {
+			input:    "[server\n",
+			expected: "syntax error: line 1: section is not closed",
+		},
+		"missing =": {
+			input:    "; comment\nkey\n",
+			expected: "syntax error: line 2: missing '='",
+		},
+		"empty key": {
+			input:    " = value",
+			expected: "syntax error: line 1: key is empty",
+		},
+		"not closed quote": {
+			input:    `key = "value`,
+			expected: `syntax error: line 1: invalid quoted value "value`,
+		},
+		"text after quote": {
+			input:    `key = 'value' text`,
+			expected: `syntax error: line 1: unexpected characters after quoted value: text`,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseINI(strings.NewReader(test.input), false)
+			assert(t, test.expected, err.Error())
+			assert(t, true, errors.Is(err, ErrSyntax))
+		})
+	}
+}
+
+func TestINIFileProvider_Init(t *testing.T) {
+	t.Parallel()
+
+	err := NewINIFileProvider("./testdata/malformed_input.ini").Init(nil)
+	assert(t, "INIFileProvider.Init: syntax error: line 1: section is not closed", err.Error())
+
+	err = NewINIFileProvider("doesn't exist").Init(nil)
+	assert(t, "INIFileProvider.Init: open doesn't exist: no such file or directory", err.Error())
+}
+
+func TestINIFileProvider_EmptyKey(t *testing.T) {
+	t.Parallel()
+
+	testObj := struct {
+		Name string `file_ini:""`
+	}{}
+
+	fieldType := reflect.TypeOf(&testObj).Elem().Field(0)
+	fieldVal := reflect.ValueOf(&testObj).Elem().Field(0)
+
+	err := NewINIFileProvider("./testdata/input.ini").Provide(fieldType, fieldVal)
+	assert(t, "INIFileProvider: key is empty", err.Error())
+}
@@ -0,0 +1,282 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInterpolationCycle = errors.New("interpolation cycle")
+	ErrUnknownReference   = errors.New("unknown reference")
+)
+
+// WithInterpolation expands references in values of all providers before they are set into fields:
+//   - `${HOME}` - the env variable
+//   - `${.Server.Host}` - the value of another field of the struct (path of Go field names)
+//   - `${PORT:-8080}` - the fallback which is used if the value is unset or empty, it may contain references too
+//   - `$$` - the escaped `$`
+//
+// Fields of struct and map types (e.g. HCL blocks) and values which custom providers set without SetField
+// are set as is.
+func WithInterpolation() ConfiguratorOption {
+	return func(o *configuratorOptions) {
+		o.interpolate = true
+	}
+}
+
+func (c *Configurator[T]) applyResolved(field reflect.StructField, v reflect.Value, path string) error {
+	val, ok, err := c.resolvedValue(field, path)
+	if err != nil {
+		return fmt.Errorf("field [%s]: %w", field.Name, err)
+	}
+
+	if !ok {
+		return fmt.Errorf("field [%s] with tags [%s] hasn't been set", field.Name, field.Tag)
+	}
+
+	if direct, ok := c.direct[path]; ok {
+		v.Set(direct)
+		return nil
+	}
+
+	return SetField(field, v, val)
+}
+
+// resolvedValue returns the expanded and decrypted value of the first provider which value can be set into the field.
+func (c *Configurator[T]) resolvedValue(field reflect.StructField, path string) (string, bool, error) {
+	if val, ok := c.resolved[path]; ok {
+		return val, true, nil
+	}
+
+	if slices.Contains(c.resolving, path) {
+		return "", false, fmt.Errorf("%w: %s", ErrInterpolationCycle, strings.Join(append(c.resolving, path), " -> "))
+	}
+
+	c.resolving = append(c.resolving, path)
+	defer func() { c.resolving = c.resolving[:len(c.resolving)-1] }()
+
+	for _, provider := range c.providers {
+		if _, found := fetchTagKey(field.Tag, c.registeredTags)[provider.Tag()]; !found {
+			// skip provider if it's not specified in tags
+			continue
+		}
+
+		raw, direct, err := provideRaw(provider, field)
+		if errors.Is(err, ErrProviderFailed) {
+			return "", false, err
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if direct.IsValid() {
+			// the provider has set the value itself, so it's used as is
+			val := fmt.Sprint(direct.Interface())
+			c.resolved[path], c.direct[path] = val, direct
+
+			return val, true, nil
+		}
+
+		val, err := c.resolve(raw)
+		if err != nil {
+			return "", false, err
+		}
+
+		// the value is checked the same way as providers do it, so the next provider is used if it doesn't fit
+		if err := SetField(field, reflect.New(field.Type).Elem(), val); err != nil {
+			continue
+		}
+
+		c.resolved[path] = val
+
+		return val, true, nil
+	}
+
+	return "", false, nil
+}
+
+// resolve expands references and then decrypts encrypted payloads of the raw value.
+func (c *Configurator[T]) resolve(raw string) (string, error) {
+	val := raw
+
+	if c.interpolate {
+		var err error
+		if val, err = c.expand(val); err != nil {
+			return "", err
+		}
+	}
+
+	if c.decrypter != nil {
+		return decryptValue(val, c.decrypter)
+	}
+
+	return val, nil
+}
+
+// provideRaw returns the value which the provider passes to SetField. The provider gets a value of the field type,
+// so providers which depend on it (e.g. INI keys repeated for slices) produce the same value as without options.
+// If the provider sets the value without SetField, the value is returned instead of the string.
+func provideRaw(provider Provider, field reflect.StructField) (string, reflect.Value, error) {
+	v := reflect.New(field.Type).Elem()
+
+	capture := &rawCapture{}
+	rawCaptures.Store(v.Addr().UnsafePointer(), capture)
+	defer rawCaptures.Delete(v.Addr().UnsafePointer())
+
+	if err := provider.Provide(field, v); err != nil {
+		return "", reflect.Value{}, err
+	}
+
+	if !capture.set {
+		return "", v, nil
+	}
+
+	return capture.val, reflect.Value{}, nil
+}
+
+// rawCaptures maps addresses of values which provideRaw passes to providers to their captures.
+var rawCaptures sync.Map
+
+// rawCapture is the string which is passed to SetField.
+type rawCapture struct {
+	val string
+	set bool
+}
+
+// captureRaw records the string if val is the value of provideRaw.
+func captureRaw(val reflect.Value, valStr string) {
+	if !val.CanAddr() {
+		return
+	}
+
+	if capture, ok := rawCaptures.Load(val.Addr().UnsafePointer()); ok {
+		capture.(*rawCapture).val, capture.(*rawCapture).set = valStr, true
+	}
+}
+
+func (c *Configurator[T]) expand(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		switch s[i+1] {
+		case '$':
+			sb.WriteByte('$')
+			i++
+
+		case '{':
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("%w: reference is not closed: %s", ErrSyntax, s[i:])
+			}
+
+			val, err := c.resolveReference(s[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(val)
+			i = end
+
+		default:
+			sb.WriteByte('$')
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// closingBrace returns the index of `}` which closes the reference started before `start` or -1.
+func closingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func (c *Configurator[T]) resolveReference(ref string) (string, error) {
+	name, fallback, hasFallback := strings.Cut(ref, ":-")
+	if len(name) == 0 {
+		return "", fmt.Errorf("%w: empty reference: ${%s}", ErrSyntax, ref)
+	}
+
+	val := os.Getenv(name)
+	if strings.HasPrefix(name, ".") {
+		var err error
+		if val, err = c.referencedValue(name[1:]); err != nil {
+			return "", err
+		}
+	}
+
+	if len(val) == 0 && hasFallback {
+		return c.expand(fallback)
+	}
+
+	return val, nil
+}
+
+// referencedValue returns the expanded value of the field at path, e.g. `Server.Host`.
+func (c *Configurator[T]) referencedValue(path string) (string, error) {
+	t := reflect.TypeOf(c.configPtr).Elem()
+
+	var field reflect.StructField
+	for _, name := range strings.Split(path, ".") {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		var ok bool
+		if t.Kind() == reflect.Struct {
+			field, ok = t.FieldByName(name)
+		}
+
+		if !ok || !field.IsExported() {
+			return "", fmt.Errorf("%w: %s", ErrUnknownReference, path)
+		}
+		t = field.Type
+	}
+
+	field.Tag = profileTag(field.Tag, c.profile, c.registeredTags)
+
+	if !canInterpolate(field.Type) {
+		return "", fmt.Errorf("%w: %s is not a value", ErrUnknownReference, path)
+	}
+
+	val, _, err := c.resolvedValue(field, path)
+
+	return val, err
+}
+
+// canInterpolate reports whether values of type t are set from strings.
+func canInterpolate(t reflect.Type) bool {
+	switch {
+	case isStructType(t), t.Kind() == reflect.Map:
+		return false
+	case t.Kind() == reflect.Slice:
+		return !isStructType(t.Elem())
+	}
+
+	return true
+}
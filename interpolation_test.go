@@ -0,0 +1,184 @@
+package configuration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInterpolation(t *testing.T) {
+	t.Setenv("INTERPOLATION_HOST", "example.com")
+	t.Setenv("INTERPOLATION_EMPTY", "")
+
+	type cfg struct {
+		URL    string `default:"http://${.Server.Host}:${.Server.Port}/api"`
+		Server struct {
+			Host string `env:"INTERPOLATION_HOST"`
+			Port int    `default:"${INTERPOLATION_PORT:-8080}"`
+		}
+		Cache    string        `default:"${INTERPOLATION_EMPTY:-${INTERPOLATION_HOST}}/cache"`
+		Price    string        `default:"$$5 and $${HOME}"`
+		Missing  string        `default:"[${INTERPOLATION_MISSING}]"`
+		Peers    []string      `default:"${.Server.Host};localhost"`
+		Timeout  time.Duration `default:"${INTERPOLATION_TIMEOUT:-2s}"`
+		Reserved struct {
+			Dollar string `default:"a$b$"`
+		}
+	}
+
+	got, err := NewConfigurator[cfg](NewEnvProvider(), NewDefaultProvider()).
+		SetOptions(WithInterpolation()).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, "http://example.com:8080/api", got.URL)
+	assert(t, 8080, got.Server.Port)
+	assert(t, "example.com/cache", got.Cache)
+	assert(t, "$5 and ${HOME}", got.Price)
+	assert(t, "[]", got.Missing)
+	assert(t, []string{"example.com", "localhost"}, got.Peers)
+	assert(t, 2*time.Second, got.Timeout)
+	assert(t, "a$b$", got.Reserved.Dollar)
+}
+
+func TestInterpolation_ProviderOrder(t *testing.T) {
+	t.Setenv("INTERPOLATION_DSN", "postgres://${INTERPOLATION_USER:-app}@db")
+
+	type cfg struct {
+		DSN  string `env:"INTERPOLATION_DSN" default:"sqlite://${.Name}"`
+		Name string `env:"INTERPOLATION_NAME" default:"app.db"`
+	}
+
+	got, err := NewConfigurator[cfg](NewEnvProvider(), NewDefaultProvider()).
+		SetOptions(WithInterpolation()).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{DSN: "postgres://app@db", Name: "app.db"}, got)
+}
+
+func TestInterpolation_FieldType(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Host  string   `file_ini:"server.host"`
+		Peers []string `file_ini:"server.peer"`
+		URL   string   `default:"http://${.Host}"`
+	}
+
+	got, err := NewConfigurator[cfg](NewINIFileProvider("./testdata/input.ini"), NewDefaultProvider()).
+		SetOptions(WithInterpolation()).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "127.0.0.1", Peers: []string{"a", "b", "c"}, URL: "http://127.0.0.1"}, got)
+}
+
+// directProvider sets values of `direct` tag without SetField.
+type directProvider struct{}
+
+func (directProvider) Name() string     { return "DirectProvider" }
+func (directProvider) Tag() string      { return "direct" }
+func (directProvider) Init(_ any) error { return nil }
+
+func (directProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	val, ok := field.Tag.Lookup("direct")
+	if !ok || len(val) == 0 {
+		return ErrEmptyValue
+	}
+	v.SetString(val)
+
+	return nil
+}
+
+func TestInterpolation_ProviderWithoutSetField(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Name string `direct:"${app}"`
+		Host string `direct:"" default:"localhost"`
+		URL  string `default:"http://${.Host}/${.Name}"`
+	}
+
+	tests := []struct {
+		opt         ConfiguratorOption
+		expectedURL string
+	}{
+		{opt: WithInterpolation(), expectedURL: "http://localhost/${app}"},
+		{opt: WithDecrypter(&AESGCMDecrypter{}), expectedURL: "http://${.Host}/${.Name}"},
+	}
+
+	for _, test := range tests {
+		got, err := NewConfigurator[cfg](directProvider{}, NewDefaultProvider()).
+			SetOptions(test.opt).
+			InitValues()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assert(t, &cfg{Name: "${app}", Host: "localhost", URL: test.expectedURL}, got) // Name is set as is
+	}
+}
+
+func TestInterpolation_Disabled(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Name string `default:"${HOME}$$"`
+	}
+
+	got, err := New[cfg](NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "${HOME}$$", got.Name)
+}
+
+func TestInterpolation_Errors(t *testing.T) {
+	t.Parallel()
+
+	type cycle struct {
+		A string `default:"${.B}"`
+		B string `default:"x${.C}"`
+		C string `default:"${.A}"`
+	}
+
+	_, err := NewConfigurator[cycle](NewDefaultProvider()).SetOptions(WithInterpolation()).InitValues()
+	assert(t, true, errors.Is(err, ErrInterpolationCycle))
+	assert(t, "field [A]: interpolation cycle: A -> B -> C -> A", err.Error())
+
+	type unknown struct {
+		A string `default:"${.Nope}"`
+	}
+
+	_, err = NewConfigurator[unknown](NewDefaultProvider()).SetOptions(WithInterpolation()).InitValues()
+	assert(t, "field [A]: unknown reference: Nope", err.Error())
+
+	type notValue struct {
+		A string `default:"${.B}"`
+		B struct {
+			C string `default:"c"`
+		}
+	}
+
+	_, err = NewConfigurator[notValue](NewDefaultProvider()).SetOptions(WithInterpolation()).InitValues()
+	assert(t, "field [A]: unknown reference: B is not a value", err.Error())
+
+	type notClosed struct {
+		A string `default:"${HOME"`
+	}
+
+	_, err = NewConfigurator[notClosed](NewDefaultProvider()).SetOptions(WithInterpolation()).InitValues()
+	assert(t, "field [A]: syntax error: reference is not closed: ${HOME", err.Error())
+
+	type empty struct {
+		A string `default:"${:-x}"`
+	}
+
+	_, err = NewConfigurator[empty](NewDefaultProvider()).SetOptions(WithInterpolation()).InitValues()
+	assert(t, "field [A]: syntax error: empty reference: ${:-x}", err.Error())
+}
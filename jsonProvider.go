@@ -1,10 +1,12 @@
 package configuration
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"reflect"
 	"strings"
@@ -17,14 +19,108 @@ const (
 
 var ErrFileMustHaveJSONExt = errors.New("file must have .json extension")
 
+type JSONFileProviderOption func(*FileProvider)
+
+// WithRelaxedJSON allows `//` and `/* */` comments, trailing commas and unquoted keys (JSONC/JSON5 style).
+// Syntax errors contain the line and column. Files may have .json, .jsonc or .json5 extension.
+func WithRelaxedJSON() JSONFileProviderOption {
+	return func(fp *FileProvider) {
+		fp.relaxed = true
+	}
+}
+
+// WithoutExtensionCheck allows files without .json extension.
+func WithoutExtensionCheck() JSONFileProviderOption {
+	return func(fp *FileProvider) {
+		fp.skipExtCheck = true
+	}
+}
+
+// WithIncludes enables `"$include": "db.json"` directives. Included files are resolved relative to the including file
+// and deep-merged into the object which contains the directive, keys of that object override included values.
+func WithIncludes() JSONFileProviderOption {
+	return func(fp *FileProvider) {
+		fp.includes = true
+	}
+}
+
+// WithMaxIncludeDepth enables include directives and limits nesting of included files (8 by default).
+func WithMaxIncludeDepth(depth int) JSONFileProviderOption {
+	return func(fp *FileProvider) {
+		fp.includes = true
+		fp.maxIncludeDepth = depth
+	}
+}
+
 // NewJSONFileProvider creates new provider which reads values from JSON files.
-func NewJSONFileProvider(fileName string) (fp *FileProvider) {
-	return &FileProvider{fileName: fileName}
+func NewJSONFileProvider(fileName string, opts ...JSONFileProviderOption) (fp *FileProvider) {
+	return newFileProvider(fileName, func() (io.ReadCloser, error) {
+		return os.Open(fileName)
+	}, opts)
+}
+
+// NewJSONFileProviderFromFS creates new provider which reads values from the JSON file inside fsys
+// (e.g. defaults embedded into the binary with embed.FS).
+func NewJSONFileProviderFromFS(fsys fs.FS, fileName string, opts ...JSONFileProviderOption) *FileProvider {
+	fp := newFileProvider(fileName, func() (io.ReadCloser, error) {
+		return fsys.Open(fileName)
+	}, opts)
+	fp.readFile = func(name string) ([]byte, error) {
+		return fs.ReadFile(fsys, name)
+	}
+	fp.resolveInclude = resolveFSInclude
+
+	return fp
+}
+
+// NewJSONFileProviderFromReader creates new provider which reads values from JSON document in r.
+// The reader is consumed by Init. Included files are resolved relative to the working directory.
+func NewJSONFileProviderFromReader(r io.Reader, opts ...JSONFileProviderOption) *FileProvider {
+	fp := newFileProvider("", func() (io.ReadCloser, error) {
+		return io.NopCloser(r), nil
+	}, opts)
+	fp.skipExtCheck = true
+
+	return fp
+}
+
+// NewJSONFileProviderFromBytes creates new provider which reads values from JSON document in b.
+func NewJSONFileProviderFromBytes(b []byte, opts ...JSONFileProviderOption) *FileProvider {
+	fp := newFileProvider("", func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}, opts)
+	fp.skipExtCheck = true
+
+	return fp
+}
+
+func newFileProvider(fileName string, open func() (io.ReadCloser, error), opts []JSONFileProviderOption) *FileProvider {
+	fp := &FileProvider{
+		fileName:        fileName,
+		open:            open,
+		readFile:        os.ReadFile,
+		resolveInclude:  resolveOSInclude,
+		maxIncludeDepth: defaultMaxIncludeDepth,
+	}
+
+	for _, f := range opts {
+		f(fp)
+	}
+
+	return fp
 }
 
 type FileProvider struct {
-	fileName string
-	fileData any
+	fileName        string
+	open            func() (io.ReadCloser, error)
+	fileData        any
+	relaxed         bool
+	skipExtCheck    bool
+	includes        bool
+	maxIncludeDepth int
+	readFile        func(name string) ([]byte, error)
+	resolveInclude  func(base, name string) string
+	profile         string
 }
 
 func (*FileProvider) Name() string {
@@ -36,7 +132,7 @@ func (*FileProvider) Tag() string {
 }
 
 func (fp *FileProvider) Init(_ any) error {
-	file, err := os.Open(fp.fileName)
+	file, err := fp.open()
 	if err != nil {
 		return fmt.Errorf("%s.Init: %w", JSONFileProviderName, err)
 	}
@@ -47,17 +143,80 @@ func (fp *FileProvider) Init(_ any) error {
 		return fmt.Errorf("%s.Init: %w", JSONFileProviderName, err)
 	}
 
-	if !strings.HasSuffix(strings.ToLower(fp.fileName), ".json") {
+	if !fp.skipExtCheck && !fp.hasJSONExt() {
 		return ErrFileMustHaveJSONExt
 	}
 
-	if err := json.Unmarshal(b, &fp.fileData); err != nil {
+	if fp.fileData, err = fp.decode(b, fp.fileName); err != nil {
+		return fmt.Errorf("%s.Init: %w", JSONFileProviderName, err)
+	}
+
+	if len(fp.profile) == 0 || len(fp.fileName) == 0 {
+		return nil
+	}
+
+	// the profile-specific file is optional and overrides values of the main one
+	profileName := profileFileName(fp.fileName, fp.profile)
+
+	b, err = fp.readFile(profileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("%s.Init: %w", JSONFileProviderName, err)
 	}
 
+	profileData, err := fp.decode(b, profileName)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %s: %w", JSONFileProviderName, profileName, err)
+	}
+	fp.fileData = mergeDocuments(fp.fileData, profileData, ArrayReplace, profileName, "", map[string]string{})
+
 	return nil
 }
 
+// decode unmarshals the document which was read from the file and resolves its include directives.
+func (fp *FileProvider) decode(b []byte, fileName string) (any, error) {
+	unmarshal := json.Unmarshal
+	if fp.relaxed {
+		unmarshal = unmarshalRelaxedJSON
+	}
+
+	var doc any
+	if err := unmarshal(b, &doc); err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	if !fp.includes {
+		return doc, nil
+	}
+
+	// the root is resolved the same way as included files, so the cycle detection compares clean paths
+	root := fp.resolveInclude("", fileName)
+	resolver := &includeResolver{
+		readFile:  fp.readFile,
+		resolve:   fp.resolveInclude,
+		unmarshal: unmarshal,
+		maxDepth:  fp.maxIncludeDepth,
+		stack:     []string{root},
+	}
+
+	return resolver.resolveIncludes(doc, root)
+}
+
+func (fp *FileProvider) setProfile(profile string) {
+	fp.profile = profile
+}
+
+func (fp *FileProvider) hasJSONExt() bool {
+	name := strings.ToLower(fp.fileName)
+	if fp.relaxed && (strings.HasSuffix(name, ".jsonc") || strings.HasSuffix(name, ".json5")) {
+		return true
+	}
+
+	return strings.HasSuffix(name, ".json")
+}
+
 func (fp *FileProvider) Provide(field reflect.StructField, v reflect.Value) error {
 	path := field.Tag.Get(JSONFileProviderTag)
 	if len(path) == 0 {
@@ -84,18 +243,45 @@ func findValStrByPath(i any, path []string) (string, bool) {
 		return "", false
 	}
 
-	for k, v := range currentFieldStr {
-		currentFieldStr[strings.ToLower(k)] = v
+	val, ok := lookupKey(currentFieldStr, firstInPath)
+	if !ok {
+		return "", false
 	}
 
 	if len(path) == 1 {
-		val, ok := currentFieldStr[firstInPath]
-		if !ok {
-			return "", false
+		return valToString(val), true
+	}
+
+	return findValStrByPath(val, path[1:])
+}
+
+// lookupKey returns the value of the lower-cased key matching it case-insensitively.
+// The map isn't modified, so documents may be shared between goroutines.
+func lookupKey(m map[string]any, key string) (any, bool) {
+	if val, ok := m[key]; ok {
+		return val, true
+	}
+
+	for k, val := range m {
+		if strings.ToLower(k) == key {
+			return val, true
 		}
+	}
+
+	return nil, false
+}
+
+// valToString converts the unmarshalled value into string, arrays are joined by the slice separator.
+func valToString(val any) string {
+	items, ok := val.([]any)
+	if !ok {
+		return fmt.Sprint(val)
+	}
 
-		return fmt.Sprint(val), true
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		strs = append(strs, fmt.Sprint(item))
 	}
 
-	return findValStrByPath(currentFieldStr[firstInPath], path[1:])
+	return strings.Join(strs, sliceSeparator)
 }
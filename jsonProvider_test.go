@@ -3,8 +3,11 @@ package configuration
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 )
 
@@ -33,6 +36,28 @@ func TestJSONFileProvider_json(t *testing.T) {
 	assert(t, expected, testObj)
 }
 
+func TestJSONFileProvider_Arrays(t *testing.T) {
+	type test struct {
+		Peers   []string `file_json:"peers"`
+		Ports   []int    `file_json:"ports"`
+		Flags   []bool   `file_json:"flags"`
+		Listing string   `file_json:"peers"`
+	}
+
+	doc := `{"peers": ["a", "b"], "ports": [80, 443], "flags": [true, false]}`
+
+	got, err := New[test](NewJSONFileProviderFromBytes([]byte(doc)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &test{
+		Peers:   []string{"a", "b"},
+		Ports:   []int{80, 443},
+		Flags:   []bool{true, false},
+		Listing: "a;b",
+	}, got)
+}
+
 // nolint:errchkjson,musttag
 func TestFindValStrByPath(t *testing.T) {
 	type embedded struct {
@@ -80,6 +105,13 @@ func TestFindValStrByPath(t *testing.T) {
 			expectedStr:  "42",
 			expectedBool: true,
 		},
+		{
+			name:         "array",
+			input:        map[string]any{"list": []any{"a", 1.5, true}},
+			path:         []string{"list"},
+			expectedStr:  "a;1.5;true",
+			expectedBool: true,
+		},
 		{
 			name:         "not found",
 			input:        testObjFromJSON,
@@ -127,3 +159,61 @@ func TestJSONProvider_empty_tag(t *testing.T) {
 	err := provider.Provide(fieldType, fieldVal)
 	assert(t, "JSONFileProvider: key is empty", err.Error())
 }
+
+func TestJSONFileProvider_Sources(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Name string `file_json:"name"`
+		Beta int    `file_json:"inside.beta"`
+	}
+
+	const doc = `{"name": "test_name_json", "inside": {"beta": 42}}`
+	fsys := fstest.MapFS{
+		"config/defaults.json": {Data: []byte(doc)},
+		"config/defaults.cfg":  {Data: []byte(doc)},
+	}
+
+	tests := map[string]Provider{
+		"reader":                     NewJSONFileProviderFromReader(strings.NewReader(doc)),
+		"bytes":                      NewJSONFileProviderFromBytes([]byte(doc)),
+		"fs":                         NewJSONFileProviderFromFS(fsys, "config/defaults.json"),
+		"fs without extension check": NewJSONFileProviderFromFS(fsys, "config/defaults.cfg", WithoutExtensionCheck()),
+		"file":                       NewJSONFileProvider("./testdata/input.json"),
+	}
+
+	for name, provider := range tests {
+		provider := provider
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := New[cfg](provider)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert(t, &cfg{Name: "test_name_json", Beta: 42}, got)
+		})
+	}
+}
+
+func TestJSONFileProvider_SourcesErrors(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"defaults.cfg": {Data: []byte(`{}`)},
+	}
+
+	err := NewJSONFileProviderFromFS(fsys, "defaults.cfg").Init(nil)
+	assert(t, ErrFileMustHaveJSONExt, err)
+
+	err = NewJSONFileProviderFromFS(fsys, "nope.json").Init(nil)
+	assert(t, "JSONFileProvider.Init: open nope.json: file does not exist", err.Error())
+
+	err = NewJSONFileProviderFromBytes([]byte(`{`)).Init(nil)
+	assert(t, "JSONFileProvider.Init: unexpected end of JSON input", err.Error())
+
+	err = NewJSONFileProvider("./testdata/dummy.file", WithoutExtensionCheck()).Init(nil)
+	assert(t, true, err != nil && !errors.Is(err, ErrFileMustHaveJSONExt))
+}
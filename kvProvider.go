@@ -0,0 +1,181 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ErrKVSource wraps ErrProviderFailed, so failures of the source aren't hidden by the next provider.
+var ErrKVSource = fmt.Errorf("%w: key/value source failed", ErrProviderFailed)
+
+// KVSource is a key/value store (etcd, Redis, SSM, etc.) which is turned into a Provider by NewKVProvider.
+type KVSource interface {
+	// Get returns the value of the key and whether it exists.
+	Get(ctx context.Context, key string) (string, bool, error)
+}
+
+// KVLister is implemented by sources which can return all keys under the prefix at once.
+// Such sources are read once during Init.
+type KVLister interface {
+	List(ctx context.Context, prefix string) (map[string]string, error)
+}
+
+type KVProviderOption func(*KVProvider)
+
+// WithKVPrefix is prepended to keys of tags: `app/` + `db/host`.
+func WithKVPrefix(prefix string) KVProviderOption {
+	return func(kp *KVProvider) {
+		kp.prefix = prefix
+	}
+}
+
+// WithKVTimeout sets the timeout of every request to the source (10s by default).
+func WithKVTimeout(timeout time.Duration) KVProviderOption {
+	return func(kp *KVProvider) {
+		kp.timeout = timeout
+	}
+}
+
+// WithKVCacheTTL keeps values (and missing keys) between Init calls for ttl.
+// By default values are cached only until the next Init.
+func WithKVCacheTTL(ttl time.Duration) KVProviderOption {
+	return func(kp *KVProvider) {
+		kp.ttl = ttl
+	}
+}
+
+// WithKVNotFound sets the function which reports whether the error of the source means a missing key
+// (e.g. `redis.Nil`). Such keys are missing values, other errors are failures of the source.
+func WithKVNotFound(isNotFound func(error) bool) KVProviderOption {
+	return func(kp *KVProvider) {
+		kp.isNotFound = isNotFound
+	}
+}
+
+// NewKVProvider creates new provider which reads values from the source by keys of the tag.
+// Missing keys are reported as ErrEmptyValue, so the next provider is used,
+// failures of the source are reported as ErrKVSource and stop filling up the configuration.
+func NewKVProvider(name, tag string, source KVSource, opts ...KVProviderOption) *KVProvider {
+	kp := &KVProvider{
+		name:       name,
+		tag:        tag,
+		source:     source,
+		timeout:    defaultTimeout,
+		isNotFound: func(error) bool { return false },
+		cache:      map[string]kvEntry{},
+		now:        time.Now,
+	}
+
+	for _, f := range opts {
+		f(kp)
+	}
+
+	return kp
+}
+
+type KVProvider struct {
+	name       string
+	tag        string
+	source     KVSource
+	prefix     string
+	timeout    time.Duration
+	ttl        time.Duration
+	isNotFound func(error) bool
+	cache      map[string]kvEntry
+	listedAt   time.Time
+	now        func() time.Time
+}
+
+type kvEntry struct {
+	val   string
+	found bool
+	at    time.Time
+}
+
+func (kp *KVProvider) Name() string {
+	return kp.name
+}
+
+func (kp *KVProvider) Tag() string {
+	return kp.tag
+}
+
+func (kp *KVProvider) Init(_ any) error {
+	now := kp.now()
+
+	for key, entry := range kp.cache {
+		if kp.expired(entry.at, now) {
+			delete(kp.cache, key)
+		}
+	}
+
+	lister, ok := kp.source.(KVLister)
+	if !ok || (!kp.listedAt.IsZero() && !kp.expired(kp.listedAt, now)) {
+		return nil
+	}
+	kp.listedAt = time.Time{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kp.timeout)
+	defer cancel()
+
+	values, err := lister.List(ctx, kp.prefix)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w: %w", kp.name, ErrKVSource, err)
+	}
+
+	for key, val := range values {
+		kp.cache[key] = kvEntry{val: val, found: true, at: now}
+	}
+	kp.listedAt = now
+
+	return nil
+}
+
+func (kp *KVProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	key := field.Tag.Get(kp.tag)
+	if len(key) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", kp.name)
+	}
+
+	entry, err := kp.get(kp.prefix + key)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %w", kp.name, ErrKVSource, err)
+	}
+
+	if !entry.found {
+		return fmt.Errorf("%s: %w", kp.name, ErrEmptyValue)
+	}
+
+	return SetField(field, v, entry.val)
+}
+
+func (kp *KVProvider) get(key string) (kvEntry, error) {
+	if entry, ok := kp.cache[key]; ok {
+		return entry, nil
+	}
+
+	if !kp.listedAt.IsZero() {
+		// all keys under the prefix were listed
+		return kvEntry{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kp.timeout)
+	defer cancel()
+
+	val, found, err := kp.source.Get(ctx, key)
+	if err != nil && !kp.isNotFound(err) {
+		return kvEntry{}, err
+	}
+
+	entry := kvEntry{val: val, found: found && err == nil, at: kp.now()}
+	kp.cache[key] = entry
+
+	return entry, nil
+}
+
+func (kp *KVProvider) expired(at, now time.Time) bool {
+	return kp.ttl <= 0 || now.Sub(at) >= kp.ttl
+}
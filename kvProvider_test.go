@@ -0,0 +1,173 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errFakeNil = errors.New("nil")
+
+// fakeKV is a key/value source which counts requests.
+type fakeKV struct {
+	values map[string]string
+	gets   int
+	err    error
+}
+
+func (kv *fakeKV) Get(_ context.Context, key string) (string, bool, error) {
+	kv.gets++
+
+	if kv.err != nil {
+		return "", false, kv.err
+	}
+
+	if key == "app/nil" {
+		return "", false, errFakeNil
+	}
+
+	val, ok := kv.values[key]
+
+	return val, ok, nil
+}
+
+// fakeListKV lists keys as well.
+type fakeListKV struct {
+	fakeKV
+	lists int
+}
+
+func (kv *fakeListKV) List(_ context.Context, prefix string) (map[string]string, error) {
+	kv.lists++
+
+	if kv.err != nil {
+		return nil, kv.err
+	}
+
+	values := map[string]string{}
+	for key, val := range kv.values {
+		if strings.HasPrefix(key, prefix) {
+			values[key] = val
+		}
+	}
+
+	return values, nil
+}
+
+type _kvCfg struct {
+	Host    string `etcd:"db/host"`
+	Port    int    `etcd:"db/port" default:"5432"`
+	Host2   string `etcd:"db/host"`
+	Timeout string `etcd:"nil" default:"1s"`
+}
+
+func TestKVProvider(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeKV{values: map[string]string{"app/db/host": "db.internal"}}
+	p := NewKVProvider("EtcdProvider", "etcd", source,
+		WithKVPrefix("app/"),
+		WithKVNotFound(func(err error) bool { return errors.Is(err, errFakeNil) }),
+	)
+
+	got, err := New[_kvCfg](p, NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &_kvCfg{Host: "db.internal", Port: 5432, Host2: "db.internal", Timeout: "1s"}, got)
+	assert(t, 3, source.gets) // db/host is cached
+
+	// the cache lives until the next Init by default
+	source.values["app/db/port"] = "5433"
+
+	got, err = New[_kvCfg](p, NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, 5433, got.Port)
+	assert(t, 6, source.gets)
+}
+
+func TestKVProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeKV{err: errors.New("connection refused")}
+	p := NewKVProvider("EtcdProvider", "etcd", source)
+
+	testObj := struct {
+		Host string `etcd:"db/host"`
+	}{}
+
+	fieldType := reflect.TypeOf(&testObj).Elem().Field(0)
+	fieldVal := reflect.ValueOf(&testObj).Elem().Field(0)
+
+	err := p.Provide(fieldType, fieldVal)
+	assert(t, true, errors.Is(err, ErrKVSource))
+	assert(t, "EtcdProvider: provider failed: key/value source failed: connection refused", err.Error())
+
+	source.err = nil
+	err = p.Provide(fieldType, fieldVal)
+	assert(t, true, errors.Is(err, ErrEmptyValue))
+
+	listSource := &fakeListKV{fakeKV: fakeKV{err: errors.New("timeout")}}
+	err = NewKVProvider("EtcdProvider", "etcd", listSource).Init(nil)
+	assert(t, "EtcdProvider.Init: provider failed: key/value source failed: timeout", err.Error())
+}
+
+func TestKVProvider_FailureIsReturned(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Host string `etcd:"db/host" default:"localhost"`
+	}
+
+	for _, opts := range [][]ConfiguratorOption{nil, {WithInterpolation()}} {
+		source := &fakeKV{err: errors.New("connection refused")}
+
+		// the default value isn't used instead of the value of the failed source
+		_, err := NewConfigurator[cfg](NewKVProvider("EtcdProvider", "etcd", source), NewDefaultProvider()).
+			SetOptions(opts...).
+			InitValues()
+		assert(t, true, errors.Is(err, ErrKVSource))
+		assert(t, true, errors.Is(err, ErrProviderFailed))
+		assert(t, "field [Host]: EtcdProvider: provider failed: key/value source failed: connection refused", err.Error())
+	}
+}
+
+func TestKVProvider_List(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeListKV{fakeKV: fakeKV{values: map[string]string{"app/db/host": "db.internal", "other/x": "y"}}}
+	now := time.Now()
+	p := NewKVProvider("EtcdProvider", "etcd", source, WithKVPrefix("app/"), WithKVCacheTTL(time.Minute))
+	p.now = func() time.Time { return now }
+
+	type cfg struct {
+		Host string `etcd:"db/host"`
+		Port int    `etcd:"db/port" default:"5432"`
+	}
+
+	for range 2 {
+		got, err := New[cfg](p, NewDefaultProvider())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assert(t, &cfg{Host: "db.internal", Port: 5432}, got)
+	}
+	assert(t, 1, source.lists)
+	assert(t, 0, source.gets)
+
+	// the listing expires after the TTL
+	source.values["app/db/port"] = "5433"
+	now = now.Add(time.Minute)
+
+	got, err := New[cfg](p, NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, 5433, got.Port)
+	assert(t, 2, source.lists)
+}
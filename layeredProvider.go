@@ -0,0 +1,178 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
+)
+
+const LayeredFileProviderName = `LayeredFileProvider`
+
+// ArrayMergePolicy defines how arrays of different layers are merged.
+type ArrayMergePolicy int
+
+const (
+	// ArrayReplace replaces arrays of previous layers (default).
+	ArrayReplace ArrayMergePolicy = iota
+	// ArrayAppend appends items to arrays of previous layers.
+	ArrayAppend
+)
+
+type LayeredFileProviderOption func(*LayeredFileProvider)
+
+// WithArrayMergePolicy sets how arrays of different layers are merged.
+func WithArrayMergePolicy(policy ArrayMergePolicy) LayeredFileProviderOption {
+	return func(lp *LayeredFileProvider) {
+		lp.arrayPolicy = policy
+	}
+}
+
+// WithOptionalLayers skips files which don't exist (e.g. `local.json` which is present only on a dev machine).
+func WithOptionalLayers() LayeredFileProviderOption {
+	return func(lp *LayeredFileProvider) {
+		lp.optional = true
+	}
+}
+
+// WithLayerOptions sets options for every layer, e.g. WithRelaxedJSON().
+func WithLayerOptions(opts ...JSONFileProviderOption) LayeredFileProviderOption {
+	return func(lp *LayeredFileProvider) {
+		lp.layerOpts = append(lp.layerOpts, opts...)
+	}
+}
+
+// NewLayeredFileProvider creates new provider which reads values from several JSON files (`file_json` tag).
+// Documents are deep-merged in order of declaration: objects are merged recursively
+// and values of later files override values of earlier ones key-by-key.
+func NewLayeredFileProvider(fileNames []string, opts ...LayeredFileProviderOption) *LayeredFileProvider {
+	lp := &LayeredFileProvider{
+		fileNames: fileNames,
+		sources:   map[string]string{},
+	}
+
+	for _, f := range opts {
+		f(lp)
+	}
+
+	return lp
+}
+
+type LayeredFileProvider struct {
+	fileNames   []string
+	arrayPolicy ArrayMergePolicy
+	optional    bool
+	layerOpts   []JSONFileProviderOption
+	fileData    any
+	sources     map[string]string
+	profile     string
+}
+
+func (*LayeredFileProvider) Name() string {
+	return LayeredFileProviderName
+}
+
+func (*LayeredFileProvider) Tag() string {
+	return JSONFileProviderTag
+}
+
+func (lp *LayeredFileProvider) Init(ptr any) error {
+	// layers are merged from scratch, so a repeated Init doesn't merge them twice
+	lp.fileData, lp.sources = nil, map[string]string{}
+
+	for _, fileName := range lp.fileNames {
+		layer := NewJSONFileProvider(fileName, lp.layerOpts...)
+		layer.setProfile(lp.profile)
+
+		if err := layer.Init(ptr); err != nil {
+			if lp.optional && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return fmt.Errorf("%s.Init: %w", LayeredFileProviderName, err)
+		}
+
+		lp.fileData = mergeDocuments(lp.fileData, layer.fileData, lp.arrayPolicy, fileName, "", lp.sources)
+	}
+
+	return nil
+}
+
+func (lp *LayeredFileProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	path := field.Tag.Get(JSONFileProviderTag)
+	if len(path) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", LayeredFileProviderName)
+	}
+
+	valStr, ok := findValStrByPath(lp.fileData, strings.Split(path, "."))
+	if !ok {
+		return fmt.Errorf("%s: findValStrByPath returns empty value", LayeredFileProviderName)
+	}
+
+	return SetField(field, v, valStr)
+}
+
+func (lp *LayeredFileProvider) setProfile(profile string) {
+	lp.profile = profile
+}
+
+// Source returns the name of the file which the value at path (e.g. `db.host`) came from.
+func (lp *LayeredFileProvider) Source(path string) (string, bool) {
+	fileName, ok := lp.sources[strings.ToLower(path)]
+	return fileName, ok
+}
+
+// mergeDocuments deep-merges src into dst and records the source of every leaf value of src.
+// Keys are lower-cased because paths are matched case-insensitively.
+func mergeDocuments(dst, src any, policy ArrayMergePolicy, source, path string, sources map[string]string) any {
+	srcMap, srcIsMap := src.(map[string]any)
+	if !srcIsMap {
+		recordSource(source, path, sources)
+
+		if dstSlice, ok := dst.([]any); ok && policy == ArrayAppend {
+			if srcSlice, ok := src.([]any); ok {
+				return append(dstSlice, srcSlice...)
+			}
+		}
+
+		return src
+	}
+
+	dstMap, ok := dst.(map[string]any)
+	if !ok {
+		// objects replace values of other types
+		deleteSources(path, sources)
+		dstMap = map[string]any{}
+	}
+
+	for k, v := range srcMap {
+		k = strings.ToLower(k)
+		dstMap[k] = mergeDocuments(dstMap[k], v, policy, source, joinPath(path, k), sources)
+	}
+
+	return dstMap
+}
+
+func recordSource(source, path string, sources map[string]string) {
+	deleteSources(path, sources)
+	sources[path] = source
+}
+
+// deleteSources removes sources of the value at path and all nested values.
+func deleteSources(path string, sources map[string]string) {
+	for p := range sources {
+		if p == path || len(path) == 0 || strings.HasPrefix(p, path+".") {
+			delete(sources, p)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,132 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type _layeredCfg struct {
+	Name    string   `file_json:"name"`
+	Host    string   `file_json:"db.host"`
+	Port    int      `file_json:"db.port"`
+	SSLMode string   `file_json:"db.options.sslmode"`
+	Peers   []string `file_json:"peers"`
+}
+
+func TestLayeredFileProvider(t *testing.T) {
+	t.Parallel()
+
+	p := NewLayeredFileProvider([]string{
+		"./testdata/layers/base.json",
+		"./testdata/layers/prod.json",
+		"./testdata/layers/local.json",
+	})
+
+	got, err := New[_layeredCfg](p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &_layeredCfg{Name: "local", Host: "db.prod", Port: 5432, SSLMode: "require", Peers: []string{"c"}}, got)
+
+	for path, expected := range map[string]string{
+		"name":               "./testdata/layers/local.json",
+		"db.host":            "./testdata/layers/prod.json",
+		"DB.Port":            "./testdata/layers/base.json",
+		"db.options.sslmode": "./testdata/layers/prod.json",
+		"peers":              "./testdata/layers/prod.json",
+	} {
+		source, ok := p.Source(path)
+		assert(t, true, ok, path)
+		assert(t, expected, source, path)
+	}
+
+	_, ok := p.Source("db")
+	assert(t, false, ok)
+}
+
+func TestLayeredFileProvider_ArrayAppend(t *testing.T) {
+	t.Parallel()
+
+	got, err := New[_layeredCfg](NewLayeredFileProvider(
+		[]string{"./testdata/layers/base.json", "./testdata/layers/prod.json", "./testdata/layers/missing.json"},
+		WithArrayMergePolicy(ArrayAppend),
+		WithOptionalLayers(),
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, []string{"a", "b", "c"}, got.Peers)
+	assert(t, "base", got.Name)
+}
+
+func TestLayeredFileProvider_InitValuesTwice(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "base.json"), `{"name": "base", "peers": ["a"]}`, 0o600)
+	writeTestFile(t, filepath.Join(dir, "local.json"), `{"name": "local", "peers": ["b"]}`, 0o600)
+
+	type cfg struct {
+		Name  string   `file_json:"name"`
+		Peers []string `file_json:"peers"`
+	}
+
+	p := NewLayeredFileProvider(
+		[]string{filepath.Join(dir, "base.json"), filepath.Join(dir, "local.json")},
+		WithArrayMergePolicy(ArrayAppend),
+		WithOptionalLayers(),
+	)
+	c := NewConfigurator[cfg](p)
+
+	for range 2 {
+		got, err := c.InitValues()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assert(t, &cfg{Name: "local", Peers: []string{"a", "b"}}, got)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "local.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Name: "base", Peers: []string{"a"}}, got)
+
+	source, _ := p.Source("name")
+	assert(t, filepath.Join(dir, "base.json"), source)
+}
+
+func TestLayeredFileProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := New[_layeredCfg](NewLayeredFileProvider([]string{"./testdata/layers/base.json", "./testdata/layers/missing.json"}))
+	assert(t, "cannot init [LayeredFileProvider] provider: LayeredFileProvider.Init: JSONFileProvider.Init: "+
+		"open ./testdata/layers/missing.json: no such file or directory", err.Error())
+
+	_, err = New[_layeredCfg](NewLayeredFileProvider(
+		[]string{"./testdata/input.jsonc"},
+		WithLayerOptions(WithRelaxedJSON()),
+	))
+	assert(t, "field [Host] with tags [file_json:\"db.host\"] hasn't been set", err.Error())
+}
+
+func TestMergeDocuments_ReplaceTypes(t *testing.T) {
+	t.Parallel()
+
+	sources := map[string]string{}
+	doc := mergeDocuments(nil, map[string]any{"a": map[string]any{"b": 1.0}}, ArrayReplace, "first", "", sources)
+	doc = mergeDocuments(doc, map[string]any{"a": "scalar"}, ArrayReplace, "second", "", sources)
+	assert(t, map[string]any{"a": "scalar"}, doc)
+	assert(t, map[string]string{"a": "second"}, sources)
+
+	doc = mergeDocuments(doc, map[string]any{"a": map[string]any{"c": 2.0}}, ArrayReplace, "third", "", sources)
+	assert(t, map[string]any{"a": map[string]any{"c": 2.0}}, doc)
+	assert(t, map[string]string{"a.c": "third"}, sources)
+}
@@ -0,0 +1,98 @@
+package configuration
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+)
+
+const MapProviderName = `MapProvider`
+
+// NewMapProvider creates new provider which sets values from the map by keys of the tag: `tag:"key"`.
+// It's handy in tests instead of env variables or files and for programmatic overrides.
+// The name of the provider includes the tag (`MapProvider[env]`), so several map providers may be used together.
+// nolint:revive
+func NewMapProvider(tag string, values map[string]string) mapProvider {
+	values = maps.Clone(values)
+
+	return mapProvider{
+		tag: tag,
+		lookup: func(key string) (string, bool) {
+			val, ok := values[key]
+			return val, ok
+		},
+	}
+}
+
+// NewNestedMapProvider creates new provider which sets values from nested maps by dotted paths: `tag:"db.host"`.
+// Keys are matched case-insensitively like in JSON files, slices are set into slice fields.
+// nolint:revive
+func NewNestedMapProvider(tag string, values map[string]any) mapProvider {
+	doc := mergeDocuments(nil, normalizeDocument(values), ArrayReplace, "", "", map[string]string{})
+
+	return mapProvider{
+		tag: tag,
+		lookup: func(path string) (string, bool) {
+			return findValStrByPath(doc, strings.Split(path, "."))
+		},
+	}
+}
+
+type mapProvider struct {
+	tag    string
+	lookup func(key string) (string, bool)
+}
+
+func (mp mapProvider) Name() string {
+	return MapProviderName + "[" + mp.tag + "]"
+}
+
+func (mp mapProvider) Tag() string {
+	return mp.tag
+}
+
+func (mapProvider) Init(_ any) error {
+	return nil
+}
+
+func (mp mapProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	key := field.Tag.Get(mp.tag)
+	if len(key) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", mp.Name())
+	}
+
+	val, ok := mp.lookup(key)
+	if !ok {
+		return fmt.Errorf("%s: %w", mp.Name(), ErrEmptyValue)
+	}
+
+	return SetField(field, v, val)
+}
+
+// normalizeDocument converts maps with string keys and slices of any types (e.g. map[string]string, []int)
+// into map[string]any and []any which are expected by findValStrByPath.
+func normalizeDocument(val any) any {
+	v := reflect.ValueOf(val)
+
+	switch {
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		doc := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			doc[iter.Key().String()] = normalizeDocument(iter.Value().Interface())
+		}
+
+		return doc
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		items := make([]any, 0, v.Len())
+		for i := range v.Len() {
+			items = append(items, normalizeDocument(v.Index(i).Interface()))
+		}
+
+		return items
+	}
+
+	return val
+}
@@ -0,0 +1,146 @@
+package configuration
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMapProvider(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Host    string        `env:"HOST"`
+		Port    int           `env:"PORT" default:"8080"`
+		Timeout time.Duration `flag:"timeout" env:"TIMEOUT"`
+	}
+
+	values := map[string]string{"HOST": "localhost", "TIMEOUT": "2s"}
+	p := NewMapProvider(EnvProviderTag, values)
+	values["HOST"] = "changed" // the map is copied
+
+	got, err := New[cfg](p, NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "localhost", Port: 8080, Timeout: 2 * time.Second}, got)
+	assert(t, "MapProvider[env]", p.Name())
+}
+
+func TestMapProvider_Overrides(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Host string `override:"db.host" file_json:"db.host"`
+		Port int    `override:"db.port" file_json:"db.port"`
+		Name string `cli:"name" default:"app"`
+	}
+
+	got, err := New[cfg](
+		NewMapProvider("cli", map[string]string{"name": "billing"}),
+		NewNestedMapProvider("override", map[string]any{
+			"DB": map[string]string{"Host": "db.internal"},
+		}),
+		NewJSONFileProviderFromBytes([]byte(`{"db": {"host": "localhost", "port": 5432}}`)),
+		NewDefaultProvider(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db.internal", Port: 5432, Name: "billing"}, got)
+}
+
+func TestNestedMapProvider(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Peers []string `values:"cluster.peers"`
+		Ports []int    `values:"cluster.ports"`
+		Debug bool     `values:"debug"`
+	}
+
+	got, err := New[cfg](NewNestedMapProvider("values", map[string]any{
+		"cluster": map[string]any{
+			"peers": []string{"a", "b"},
+			"ports": []int{1, 2},
+		},
+		"debug": true,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Peers: []string{"a", "b"}, Ports: []int{1, 2}, Debug: true}, got)
+
+	testObj := struct {
+		Host string `values:"db.host"`
+		Port string `values:""`
+	}{}
+
+	p := NewNestedMapProvider("values", nil)
+	err = p.Provide(reflect.TypeOf(testObj).Field(0), reflect.ValueOf(&testObj).Elem().Field(0))
+	assert(t, true, errors.Is(err, ErrEmptyValue))
+	assert(t, "MapProvider[values]: empty value", err.Error())
+
+	err = p.Provide(reflect.TypeOf(testObj).Field(1), reflect.ValueOf(&testObj).Elem().Field(1))
+	assert(t, "MapProvider[values]: key is empty", err.Error())
+}
+
+func TestNestedMapProvider_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Host string `values:"DB.Host"`
+	}
+
+	p := NewNestedMapProvider("values", map[string]any{"Db": map[string]any{"HOST": "localhost"}})
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]*cfg, 4)
+		errs    = make([]error, 4)
+	)
+
+	for i := range results {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = New[cfg](p)
+		}()
+	}
+	wg.Wait()
+
+	for i := range results {
+		assert(t, nil, errs[i])
+		assert(t, &cfg{Host: "localhost"}, results[i])
+	}
+}
+
+func TestFindValStrByPath_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	doc := map[string]any{"DB": map[string]any{"Host": "localhost"}}
+
+	val, ok := findValStrByPath(doc, []string{"db", "host"})
+	assert(t, true, ok)
+	assert(t, "localhost", val)
+	assert(t, map[string]any{"DB": map[string]any{"Host": "localhost"}}, doc)
+}
+
+func TestNormalizeDocument(t *testing.T) {
+	t.Parallel()
+
+	assert(t, map[string]any{
+		"a": map[string]any{"b": "c"},
+		"d": []any{1, 2},
+		"e": []byte("f"),
+		"g": 1.5,
+	}, normalizeDocument(map[string]any{
+		"a": map[string]string{"b": "c"},
+		"d": []int{1, 2},
+		"e": []byte("f"),
+		"g": 1.5,
+	}))
+}
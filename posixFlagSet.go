@@ -0,0 +1,282 @@
+package configuration
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// POSIXFlagSet is a FlagSet which parses arguments in POSIX/GNU style:
+//   - single-character names are short flags: `-v`, `-p 8080`, `-p8080`
+//   - short switches may be combined: `-abc` is the same as `-a -b -c`
+//   - other names are long flags: `--port 8080`, `--port=8080`, `--verbose`
+//   - `--` terminates flags, the rest of arguments are available via Args()
+//
+// Parsing stops at the first non-flag argument.
+type POSIXFlagSet struct {
+	// Usage is called when help is requested (-h, --help) or parsing fails.
+	// It prints all defined flags by default.
+	Usage func()
+
+	name          string
+	errorHandling flag.ErrorHandling
+	output        io.Writer
+	flags         map[string]*posixFlag
+	ordered       []*posixFlag
+	args          []string
+}
+
+type posixFlag struct {
+	names    []string
+	usage    string
+	value    flag.Value
+	defValue string
+}
+
+// NewPOSIXFlagSet creates a new POSIXFlagSet. Errors are handled the same way as in flag.NewFlagSet.
+func NewPOSIXFlagSet(name string, errorHandling flag.ErrorHandling) *POSIXFlagSet {
+	fs := &POSIXFlagSet{
+		name:          name,
+		errorHandling: errorHandling,
+		flags:         map[string]*posixFlag{},
+	}
+	fs.Usage = fs.defaultUsage
+
+	return fs
+}
+
+// SetOutput sets the destination for usage and error messages. os.Stderr is used by default.
+func (f *POSIXFlagSet) SetOutput(w io.Writer) {
+	f.output = w
+}
+
+// Output returns the destination for usage and error messages.
+func (f *POSIXFlagSet) Output() io.Writer {
+	if f.output == nil {
+		return os.Stderr
+	}
+
+	return f.output
+}
+
+// String defines a string flag with specified name, default value, and usage string.
+func (f *POSIXFlagSet) String(name string, value string, usage string) *string {
+	val := &scalarFlagValue{val: value}
+	f.Var(val, name, usage)
+
+	return &val.val
+}
+
+// Var defines a flag with the specified name and usage string.
+// Several names defined with the same value are treated as aliases.
+// Values implementing `IsBoolFlag() bool` are switches and don't need an argument.
+func (f *POSIXFlagSet) Var(value flag.Value, name string, usage string) {
+	if _, ok := f.flags[name]; ok {
+		panic(fmt.Sprintf("%s flag redefined: %s", f.name, name))
+	}
+
+	for _, pf := range f.ordered {
+		if pf.value == value {
+			pf.names = append(pf.names, name)
+			f.flags[name] = pf
+			return
+		}
+	}
+
+	pf := &posixFlag{
+		names:    []string{name},
+		usage:    usage,
+		value:    value,
+		defValue: value.String(),
+	}
+	f.flags[name] = pf
+	f.ordered = append(f.ordered, pf)
+}
+
+// Args returns the non-flag arguments.
+func (f *POSIXFlagSet) Args() []string {
+	return f.args
+}
+
+// Parse parses flag definitions from the argument list, which should not include the command name.
+func (f *POSIXFlagSet) Parse(arguments []string) error {
+	err := f.parse(arguments)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(f.Output(), err)
+	}
+	f.Usage()
+
+	// nolint:exhaustive
+	switch f.errorHandling {
+	case flag.ExitOnError:
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2) // nolint:mnd
+	case flag.PanicOnError:
+		panic(err)
+	}
+
+	return err
+}
+
+func (f *POSIXFlagSet) parse(arguments []string) error {
+	for i := 0; i < len(arguments); i++ {
+		arg := arguments[i]
+
+		switch {
+		case arg == "--":
+			f.args = arguments[i+1:]
+			return nil
+
+		case len(arg) < 2 || arg[0] != '-': // nolint:mnd
+			f.args = arguments[i:]
+			return nil
+
+		case strings.HasPrefix(arg, "--"):
+			consumed, err := f.parseLong(arg[2:], arguments[i+1:])
+			if err != nil {
+				return err
+			}
+			i += consumed
+
+		default:
+			consumed, err := f.parseShort(arg[1:], arguments[i+1:])
+			if err != nil {
+				return err
+			}
+			i += consumed
+		}
+	}
+
+	f.args = nil
+	return nil
+}
+
+// parseLong parses `--name`, `--name=value` or `--name value` and returns the number of consumed arguments.
+func (f *POSIXFlagSet) parseLong(arg string, rest []string) (int, error) {
+	name, value, hasValue := strings.Cut(arg, "=")
+
+	pf, err := f.lookup(name, "--")
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case hasValue:
+		return 0, f.set(pf, "--"+name, value)
+	case isSwitch(pf.value):
+		return 0, f.set(pf, "--"+name, "true")
+	case len(rest) == 0:
+		return 0, fmt.Errorf("flag needs an argument: --%s", name)
+	default:
+		return 1, f.set(pf, "--"+name, rest[0])
+	}
+}
+
+// parseShort parses a cluster of short flags (`-abc`, `-p8080`, `-p 8080`)
+// and returns the number of consumed arguments.
+func (f *POSIXFlagSet) parseShort(cluster string, rest []string) (int, error) {
+	for i, r := range cluster {
+		name := string(r)
+
+		pf, err := f.lookup(name, "-")
+		if err != nil {
+			return 0, err
+		}
+
+		if isSwitch(pf.value) {
+			if err := f.set(pf, "-"+name, "true"); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		// the remainder of the cluster is the value: -p8080 or -p=8080
+		if value := cluster[i+len(name):]; len(value) > 0 {
+			return 0, f.set(pf, "-"+name, strings.TrimPrefix(value, "="))
+		}
+
+		if len(rest) == 0 {
+			return 0, fmt.Errorf("flag needs an argument: -%s", name)
+		}
+
+		return 1, f.set(pf, "-"+name, rest[0])
+	}
+
+	return 0, nil
+}
+
+func (f *POSIXFlagSet) lookup(name, prefix string) (*posixFlag, error) {
+	pf, ok := f.flags[name]
+	if ok {
+		return pf, nil
+	}
+
+	if name == "h" || name == "help" {
+		return nil, flag.ErrHelp
+	}
+
+	return nil, fmt.Errorf("flag provided but not defined: %s%s", prefix, name)
+}
+
+func (f *POSIXFlagSet) set(pf *posixFlag, name, value string) error {
+	if err := pf.value.Set(value); err != nil {
+		return fmt.Errorf("invalid value %q for flag %s: %w", value, name, err)
+	}
+
+	return nil
+}
+
+// PrintDefaults prints all defined flags with their aliases to the output.
+func (f *POSIXFlagSet) PrintDefaults() {
+	out := f.Output()
+
+	for _, pf := range f.ordered {
+		names := make([]string, 0, len(pf.names))
+		for _, name := range pf.names {
+			names = append(names, posixFlagName(name))
+		}
+
+		line := "  " + strings.Join(names, ", ")
+		if len(pf.usage) > 0 {
+			line += "\n    \t" + pf.usage
+		}
+
+		if len(pf.defValue) > 0 && !isSwitch(pf.value) {
+			line += fmt.Sprintf(" (default: %s)", pf.defValue)
+		}
+
+		fmt.Fprintln(out, line)
+	}
+}
+
+func (f *POSIXFlagSet) defaultUsage() {
+	if len(f.name) == 0 {
+		fmt.Fprintln(f.Output(), "Usage:")
+	} else {
+		fmt.Fprintf(f.Output(), "Usage of %s:\n", f.name)
+	}
+	f.PrintDefaults()
+}
+
+// posixFlagName returns the name as it's passed in arguments: `-v` for short names and `--verbose` for long ones.
+func posixFlagName(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+
+	return "--" + name
+}
+
+func isSwitch(v flag.Value) bool {
+	bf, ok := v.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
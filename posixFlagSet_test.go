@@ -0,0 +1,151 @@
+package configuration
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"testing"
+)
+
+func TestPOSIXFlagSet_Parse(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args         []string
+		expectedA    string
+		expectedB    string
+		expectedPort string
+		expectedArgs []string
+	}{
+		"combined switches": {
+			args:      []string{"-ab"},
+			expectedA: "true",
+			expectedB: "true",
+		},
+		"long flags": {
+			args:         []string{"--alpha", "--port=80"},
+			expectedA:    "true",
+			expectedPort: "80",
+		},
+		"long flag with separate value": {
+			args:         []string{"--port", "81", "cmd"},
+			expectedPort: "81",
+			expectedArgs: []string{"cmd"},
+		},
+		"short flag with attached value": {
+			args:         []string{"-bp82"},
+			expectedB:    "true",
+			expectedPort: "82",
+		},
+		"short flag with separate value": {
+			args:         []string{"-p", "83", "-a"},
+			expectedA:    "true",
+			expectedPort: "83",
+		},
+		"terminator": {
+			args:         []string{"-a", "--", "-b", "x"},
+			expectedA:    "true",
+			expectedArgs: []string{"-b", "x"},
+		},
+		"stops at first positional": {
+			args:         []string{"serve", "-a"},
+			expectedArgs: []string{"serve", "-a"},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				fs   = NewPOSIXFlagSet("test", flag.ContinueOnError)
+				a    = &scalarFlagValue{isBool: true}
+				b    = &scalarFlagValue{isBool: true}
+				port = &scalarFlagValue{}
+			)
+			fs.Var(a, "a", "")
+			fs.Var(a, "alpha", "")
+			fs.Var(b, "b", "")
+			fs.Var(port, "p", "")
+			fs.Var(port, "port", "")
+
+			if err := fs.Parse(test.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert(t, test.expectedA, a.val)
+			assert(t, test.expectedB, b.val)
+			assert(t, test.expectedPort, port.val)
+			assert(t, test.expectedArgs, fs.Args())
+		})
+	}
+}
+
+func TestPOSIXFlagSet_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args     []string
+		expected string
+	}{
+		"unknown long flag": {
+			args:     []string{"--unknown"},
+			expected: "flag provided but not defined: --unknown",
+		},
+		"unknown short flag": {
+			args:     []string{"-vx"},
+			expected: "flag provided but not defined: -x",
+		},
+		"missing argument": {
+			args:     []string{"--name"},
+			expected: "flag needs an argument: --name",
+		},
+		"missing short argument": {
+			args:     []string{"-n"},
+			expected: "flag needs an argument: -n",
+		},
+		"help": {
+			args:     []string{"-h"},
+			expected: flag.ErrHelp.Error(),
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := NewPOSIXFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(&bytes.Buffer{})
+			fs.Var(&scalarFlagValue{isBool: true}, "v", "")
+			name := &scalarFlagValue{}
+			fs.Var(name, "name", "")
+			fs.Var(name, "n", "")
+
+			err := fs.Parse(test.args)
+			assert(t, test.expected, err.Error())
+		})
+	}
+}
+
+func TestPOSIXFlagSet_Usage(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	fs := NewPOSIXFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(out)
+
+	verbose := &scalarFlagValue{isBool: true}
+	fs.Var(verbose, "verbose", "verbose output")
+	fs.Var(verbose, "v", "verbose output")
+	fs.String("port", "8080", "port to listen")
+
+	err := fs.Parse([]string{"--help"})
+	assert(t, true, errors.Is(err, flag.ErrHelp))
+	assert(t, "Usage of app:\n"+
+		"  --verbose, -v\n    \tverbose output\n"+
+		"  --port\n    \tport to listen (default: 8080)\n", out.String())
+}
@@ -0,0 +1,65 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WithProfile sets the active profile (e.g. `prod`). Tags qualified by the profile (`default.prod:"..."`)
+// take precedence over unqualified ones and file providers merge `config.prod.json` over `config.json`.
+// Flags are registered from unqualified `flag` tags only.
+func WithProfile(profile string) ConfiguratorOption {
+	return func(o *configuratorOptions) {
+		o.profile = profile
+	}
+}
+
+// WithProfileFromEnv reads the active profile from the env variable (e.g. `APP_PROFILE`).
+// The profile of WithProfile is used if the variable is unset or empty.
+func WithProfileFromEnv(name string) ConfiguratorOption {
+	return func(o *configuratorOptions) {
+		o.profileEnv = name
+	}
+}
+
+func (o configuratorOptions) activeProfile() string {
+	if len(o.profileEnv) > 0 {
+		if profile := os.Getenv(o.profileEnv); len(profile) > 0 {
+			return profile
+		}
+	}
+
+	return o.profile
+}
+
+// profileTag puts values of profile-qualified tags in front of the tag, so they are found first by Lookup.
+func profileTag(tag reflect.StructTag, profile string, registered map[string]struct{}) reflect.StructTag {
+	if len(profile) == 0 {
+		return tag
+	}
+
+	keys := make([]string, 0, len(registered))
+	for rt := range registered {
+		keys = append(keys, rt)
+	}
+	slices.Sort(keys)
+
+	for _, rt := range keys {
+		if val, ok := tag.Lookup(rt + "." + profile); ok {
+			tag = reflect.StructTag(fmt.Sprintf("%s:%s %s", rt, strconv.Quote(val), tag))
+		}
+	}
+
+	return tag
+}
+
+// profileFileName inserts the profile before the extension: `config.json` -> `config.prod.json`.
+func profileFileName(fileName, profile string) string {
+	ext := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, ext) + "." + profile + ext
+}
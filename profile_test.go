@@ -0,0 +1,151 @@
+package configuration
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+type _profileCfg struct {
+	Host     string `file_json:"db.host"`
+	Port     int    `file_json:"db.port"`
+	Debug    bool   `file_json:"debug"`
+	LogLevel string `default:"debug" default.prod:"warn" default.staging:"info"`
+	Replicas int    `default.prod:"3"`
+}
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewConfigurator[_profileCfg](NewJSONFileProvider("./testdata/profile/config.json"), NewDefaultProvider()).
+		SetOptions(WithProfile("prod")).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &_profileCfg{
+		Host:     "db.prod.internal",
+		Port:     5432,
+		Debug:    false,
+		LogLevel: "warn",
+		Replicas: 3,
+	}, got)
+}
+
+func TestProfile_Missing(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Host     string `file_json:"db.host"`
+		LogLevel string `default:"debug" default.prod:"warn" default.staging:"info"`
+	}
+
+	got, err := NewConfigurator[cfg](NewJSONFileProvider("./testdata/profile/config.json"), NewDefaultProvider()).
+		SetOptions(WithProfile("staging")).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "localhost", LogLevel: "info"}, got)
+
+	_, err = New[_profileCfg](NewJSONFileProvider("./testdata/profile/config.json"), NewDefaultProvider())
+	assert(t, "field [Replicas] with tags [default.prod:\"3\"] hasn't been set", err.Error())
+}
+
+func TestProfileFromEnv(t *testing.T) {
+	t.Setenv("PROFILE_TEST_ENV", "prod")
+
+	type cfg struct {
+		Host string `file_json:"db.host"`
+		URL  string `default:"http://${.Host}" default.dev:"http://localhost"`
+	}
+
+	fsys := fstest.MapFS{
+		"config.json":      {Data: []byte(`{"db": {"host": "localhost"}}`)},
+		"config.prod.json": {Data: []byte(`{"db": {"host": "db.prod.internal"}}`)},
+	}
+
+	got, err := NewConfigurator[cfg](NewJSONFileProviderFromFS(fsys, "config.json"), NewDefaultProvider()).
+		SetOptions(WithProfile("dev"), WithProfileFromEnv("PROFILE_TEST_ENV"), WithInterpolation()).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db.prod.internal", URL: "http://db.prod.internal"}, got)
+
+	t.Setenv("PROFILE_TEST_ENV", "")
+
+	got, err = NewConfigurator[cfg](NewJSONFileProviderFromFS(fsys, "config.json"), NewDefaultProvider()).
+		SetOptions(WithProfile("dev"), WithProfileFromEnv("PROFILE_TEST_ENV")).
+		InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "localhost", URL: "http://localhost"}, got)
+}
+
+// nolint:paralleltest
+func TestProfileFromEnv_InitValuesTwice(t *testing.T) {
+	t.Setenv("PROFILE_TEST_ENV", "prod")
+
+	type cfg struct {
+		Host string `file_json:"db.host"`
+	}
+
+	c := NewConfigurator[cfg](NewJSONFileProvider("./testdata/profile/config.json")).
+		SetOptions(WithProfileFromEnv("PROFILE_TEST_ENV"))
+
+	got, err := c.InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db.prod.internal"}, got)
+
+	t.Setenv("PROFILE_TEST_ENV", "")
+
+	got, err = c.InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "localhost"}, got)
+}
+
+func TestProfile_Layered(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Host  string `file_json:"db.host"`
+		Port  int    `file_json:"db.port"`
+		Debug bool   `file_json:"debug"`
+	}
+
+	p := NewLayeredFileProvider([]string{"./testdata/profile/config.json", "./testdata/layers/local.json"}, WithOptionalLayers())
+
+	got, err := NewConfigurator[cfg](p).SetOptions(WithProfile("prod")).InitValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{Host: "db.prod.internal", Port: 5432, Debug: false}, got)
+}
+
+func TestProfileTag(t *testing.T) {
+	t.Parallel()
+
+	registered := map[string]struct{}{"default": {}, "env": {}}
+	tag := reflect.StructTag(`env:"A" env.prod:"B" default:"x" default.prod:"y \"q\""`)
+
+	got := profileTag(tag, "prod", registered)
+	assert(t, "y \"q\"", got.Get("default"))
+	assert(t, "B", got.Get("env"))
+	assert(t, tag, profileTag(tag, "", registered))
+	assert(t, tag, profileTag(tag, "dev", registered))
+}
+
+func TestProfileFileName(t *testing.T) {
+	t.Parallel()
+
+	assert(t, "config.prod.json", profileFileName("config.json", "prod"))
+	assert(t, "./conf/app.prod.jsonc", profileFileName("./conf/app.jsonc", "prod"))
+	assert(t, "config.prod", profileFileName("config", "prod"))
+}
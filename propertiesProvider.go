@@ -0,0 +1,209 @@
+package configuration
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	PropertiesFileProviderName = `PropertiesFileProvider`
+	PropertiesFileProviderTag  = `file_properties`
+)
+
+// NewPropertiesFileProvider creates new provider which reads values from Java-style .properties files.
+// Keys are addressed by `file_properties:"server.port"` tag, the same way as `file_json` paths.
+func NewPropertiesFileProvider(fileName string) *PropertiesFileProvider {
+	return &PropertiesFileProvider{fileName: fileName}
+}
+
+type PropertiesFileProvider struct {
+	fileName string
+	values   map[string]string
+}
+
+func (*PropertiesFileProvider) Name() string {
+	return PropertiesFileProviderName
+}
+
+func (*PropertiesFileProvider) Tag() string {
+	return PropertiesFileProviderTag
+}
+
+func (fp *PropertiesFileProvider) Init(_ any) error {
+	file, err := os.Open(fp.fileName)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w", PropertiesFileProviderName, err)
+	}
+	defer file.Close()
+
+	if fp.values, err = parseProperties(file); err != nil {
+		return fmt.Errorf("%s.Init: %w", PropertiesFileProviderName, err)
+	}
+
+	return nil
+}
+
+func (fp *PropertiesFileProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	path := field.Tag.Get(PropertiesFileProviderTag)
+	if len(path) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", PropertiesFileProviderName)
+	}
+
+	valStr, ok := fp.values[strings.ToLower(path)]
+	if !ok || len(valStr) == 0 {
+		return fmt.Errorf("%s: %w", PropertiesFileProviderName, ErrEmptyValue)
+	}
+
+	return SetField(field, v, valStr)
+}
+
+// parseProperties parses the format of java.util.Properties: `key=value`, `key: value` or `key value` pairs,
+// `#` and `!` comments, lines continued with a trailing backslash and escapes like `\t` or `\u00e9`.
+// Keys are lower-cased to be matched the same way as JSON paths.
+func parseProperties(r io.Reader) (map[string]string, error) {
+	var (
+		values     = map[string]string{}
+		logical    strings.Builder
+		continued  bool
+		lineNum    int
+		startLine  int
+		scanner    = bufio.NewScanner(r)
+		addLogical = func() error {
+			key, val, err := parsePropertyLine(logical.String())
+			if err != nil {
+				return fmt.Errorf("%w: line %d: %w", ErrSyntax, startLine, err)
+			}
+			values[strings.ToLower(key)] = val
+			logical.Reset()
+
+			return nil
+		}
+	)
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimLeft(strings.TrimSuffix(scanner.Text(), "\r"), " \t\f")
+
+		if !continued {
+			if len(line) == 0 || line[0] == '#' || line[0] == '!' {
+				continue
+			}
+			startLine = lineNum
+		}
+
+		if continued = endsWithOddBackslashes(line); continued {
+			logical.WriteString(line[:len(line)-1])
+			continue
+		}
+
+		logical.WriteString(line)
+		if err := addLogical(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+
+	if continued {
+		if err := addLogical(); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func endsWithOddBackslashes(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+
+	return n%2 == 1
+}
+
+// parsePropertyLine splits the logical line into unescaped key and value.
+func parsePropertyLine(line string) (string, string, error) {
+	keyEnd := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+
+		if strings.IndexByte("=: \t\f", line[i]) >= 0 {
+			keyEnd = i
+			break
+		}
+	}
+
+	rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+	if len(rest) > 0 && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	key, err := unescapeProperty(line[:keyEnd])
+	if err != nil {
+		return "", "", err
+	}
+
+	val, err := unescapeProperty(rest)
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, val, nil
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		if i == len(s) {
+			break
+		}
+
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\uxxxx encoding: %s", s[i-1:])
+			}
+
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\uxxxx encoding: %s", s[i-1:i+5])
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String(), nil
+}
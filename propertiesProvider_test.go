@@ -0,0 +1,121 @@
+package configuration
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPropertiesFileProvider(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Name    string        `file_properties:"name"`
+		Timeout time.Duration `file_properties:"timeout"`
+		Server  struct {
+			Host  string   `file_properties:"server.host"`
+			Port  int      `file_properties:"server.port"`
+			Peers []string `file_properties:"server.peers"`
+		}
+		Greeting string `file_properties:"greeting"`
+		Spaces   string `file_properties:"key with spaces"`
+		Empty    string `file_properties:"empty" default:"default"`
+	}
+
+	got, err := New[cfg](NewPropertiesFileProvider("./testdata/input.properties"), NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, "test_name_properties", got.Name)
+	assert(t, 101*time.Millisecond, got.Timeout)
+	assert(t, "localhost", got.Server.Host)
+	assert(t, 8080, got.Server.Port)
+	assert(t, []string{"a", "b", "c"}, got.Server.Peers)
+	assert(t, "café\tbar", got.Greeting)
+	assert(t, "value with : colon", got.Spaces)
+	assert(t, "default", got.Empty)
+}
+
+func TestParseProperties(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected map[string]string
+	}{
+		"separators": {
+			input:    "a=1\nb:2\nc 3\nd = 4\ne\t:\t5",
+			expected: map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"},
+		},
+		"key only": {
+			input:    "flag",
+			expected: map[string]string{"flag": ""},
+		},
+		"escaped separator in key": {
+			input:    `a\=b=c`,
+			expected: map[string]string{"a=b": "c"},
+		},
+		"even backslashes don't continue": {
+			input:    "a=1\\\\\nb=2",
+			expected: map[string]string{"a": `1\`, "b": "2"},
+		},
+		"continuation at EOF": {
+			input:    "a=1\\",
+			expected: map[string]string{"a": "1"},
+		},
+		"comment isn't continued": {
+			input:    "# comment \\\na=1\r\n",
+			expected: map[string]string{"a": "1"},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseProperties(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert(t, test.expected, got)
+		})
+	}
+}
+
+func TestParseProperties_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseProperties(strings.NewReader("a=1\nb=\\u00\n"))
+	assert(t, `syntax error: line 2: malformed \uxxxx encoding: \u00`, err.Error())
+	assert(t, true, errors.Is(err, ErrSyntax))
+
+	_, err = parseProperties(strings.NewReader("a=\\uzzzz"))
+	assert(t, `syntax error: line 1: malformed \uxxxx encoding: \uzzzz`, err.Error())
+}
+
+func TestPropertiesFileProvider_Init(t *testing.T) {
+	t.Parallel()
+
+	err := NewPropertiesFileProvider("doesn't exist").Init(nil)
+	assert(t, "PropertiesFileProvider.Init: open doesn't exist: no such file or directory", err.Error())
+}
+
+func TestPropertiesFileProvider_EmptyKey(t *testing.T) {
+	t.Parallel()
+
+	testObj := struct {
+		Name string `file_properties:""`
+	}{}
+
+	fieldType := reflect.TypeOf(&testObj).Elem().Field(0)
+	fieldVal := reflect.ValueOf(&testObj).Elem().Field(0)
+
+	err := NewPropertiesFileProvider("./testdata/input.properties").Provide(fieldType, fieldVal)
+	assert(t, "PropertiesFileProvider: key is empty", err.Error())
+}
@@ -1,6 +1,13 @@
 package configuration
 
-import "reflect"
+import (
+	"reflect"
+	"time"
+)
+
+// defaultTimeout limits requests of providers which read remote sources or run commands
+// (HTTP, Consul, Vault, key/value, SQL and exec providers).
+const defaultTimeout = 10 * time.Second
 
 // Provider defines interface for existing and future custom providers.
 type Provider interface {
@@ -13,3 +20,14 @@ type Provider interface {
 	// Provide operates on reflect.StructField and reflect.Value to set appropriate value.
 	Provide(field reflect.StructField, v reflect.Value) error
 }
+
+// tagsAware is implemented by providers which depend on tags of other registered providers
+// (e.g. the flag provider mentions env variables in the usage).
+type tagsAware interface {
+	setRegisteredTags(tags map[string]struct{})
+}
+
+// profileAware is implemented by providers which load profile-specific sources (e.g. `config.prod.json`).
+type profileAware interface {
+	setProfile(profile string)
+}
@@ -0,0 +1,178 @@
+package configuration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// unmarshalRelaxedJSON unmarshals JSON with `//` and `/* */` comments, trailing commas and unquoted keys.
+// Syntax errors contain the line and column in the original document.
+func unmarshalRelaxedJSON(src []byte, v any) error {
+	out, origin, err := relaxJSON(src)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(out, v)
+
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err // nolint:wrapcheck
+	}
+
+	// Offset points right after the invalid character or to the end of input
+	offset := len(src)
+	if i := int(syntaxErr.Offset) - 1; i >= 0 && i < len(origin) && !strings.HasPrefix(syntaxErr.Error(), "unexpected end") {
+		offset = origin[i]
+	}
+	line, col := lineAndColumn(src, offset)
+
+	return fmt.Errorf("%w: %d:%d: %s", ErrSyntax, line, col, syntaxErr.Error())
+}
+
+// relaxJSON converts relaxed JSON into the strict one. It returns the offset in src for every byte of the result.
+// nolint:cyclop
+func relaxJSON(src []byte) ([]byte, []int, error) {
+	var (
+		out    = make([]byte, 0, len(src))
+		origin = make([]int, 0, len(src))
+		emit   = func(b byte, from int) {
+			out = append(out, b)
+			origin = append(origin, from)
+		}
+	)
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+
+		switch {
+		case c == '"':
+			end := skipJSONString(src, i)
+			for j := i; j < end; j++ {
+				emit(src[j], j)
+			}
+			i = end - 1
+
+		case c == '/' && i+1 < len(src) && (src[i+1] == '/' || src[i+1] == '*'):
+			end, err := skipJSONComment(src, i)
+			if err != nil {
+				return nil, nil, err
+			}
+			// comments are replaced by a space to keep tokens separated
+			emit(' ', i)
+			i = end - 1
+
+		case c == ',':
+			next, err := skipJSONSpaces(src, i+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if next < len(src) && (src[next] == '}' || src[next] == ']') {
+				continue // trailing comma
+			}
+			emit(c, i)
+
+		case isJSONIdentStart(c):
+			end := i + 1
+			for end < len(src) && isJSONIdentPart(src[end]) {
+				end++
+			}
+
+			next, err := skipJSONSpaces(src, end)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			quote := next < len(src) && src[next] == ':' // unquoted key
+			if quote {
+				emit('"', i)
+			}
+			for j := i; j < end; j++ {
+				emit(src[j], j)
+			}
+			if quote {
+				emit('"', end-1)
+			}
+			i = end - 1
+
+		default:
+			emit(c, i)
+		}
+	}
+
+	return out, origin, nil
+}
+
+// skipJSONString returns the offset after the closing quote (or the end of src if the string isn't closed).
+func skipJSONString(src []byte, start int) int {
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return len(src)
+}
+
+// skipJSONComment returns the offset after the comment which starts at `start`.
+func skipJSONComment(src []byte, start int) (int, error) {
+	if src[start+1] == '/' {
+		if end := strings.IndexByte(string(src[start:]), '\n'); end >= 0 {
+			return start + end, nil
+		}
+
+		return len(src), nil
+	}
+
+	if end := strings.Index(string(src[start+2:]), "*/"); end >= 0 {
+		return start + 2 + end + 2, nil
+	}
+
+	line, col := lineAndColumn(src, start)
+	return 0, fmt.Errorf("%w: %d:%d: comment is not closed", ErrSyntax, line, col)
+}
+
+// skipJSONSpaces returns the offset of the next significant character skipping spaces and comments.
+func skipJSONSpaces(src []byte, i int) (int, error) {
+	for i < len(src) {
+		switch {
+		case src[i] == ' ' || src[i] == '\t' || src[i] == '\n' || src[i] == '\r':
+			i++
+
+		case src[i] == '/' && i+1 < len(src) && (src[i+1] == '/' || src[i+1] == '*'):
+			end, err := skipJSONComment(src, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+
+		default:
+			return i, nil
+		}
+	}
+
+	return i, nil
+}
+
+func isJSONIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isJSONIdentPart(c byte) bool {
+	return isJSONIdentStart(c) || isDigit(c)
+}
+
+// lineAndColumn converts the byte offset into 1-based line and column.
+func lineAndColumn(src []byte, offset int) (int, int) {
+	offset = min(offset, len(src))
+	line := 1 + strings.Count(string(src[:offset]), "\n")
+	col := offset - strings.LastIndexByte(string(src[:offset]), '\n')
+
+	return line, col
+}
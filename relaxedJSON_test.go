@@ -0,0 +1,123 @@
+package configuration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJSONFileProvider_Relaxed(t *testing.T) {
+	t.Parallel()
+
+	type cfg struct {
+		Name    string        `file_json:"name"`
+		Beta    int           `file_json:"inside.$beta"`
+		URL     string        `file_json:"inside.url"`
+		Timeout time.Duration `file_json:"timeout"`
+	}
+
+	got, err := New[cfg](NewJSONFileProvider("./testdata/input.jsonc", WithRelaxedJSON()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &cfg{Name: "test_name_jsonc", Beta: 42, URL: "http://localhost//not-a-comment", Timeout: 101 * time.Millisecond}, got)
+}
+
+func TestJSONFileProvider_StrictByDefault(t *testing.T) {
+	t.Parallel()
+
+	err := NewJSONFileProvider("./testdata/input.jsonc").Init(nil)
+	assert(t, ErrFileMustHaveJSONExt, err)
+
+	err = NewJSONFileProvider("./testdata/malformed_input.json", WithRelaxedJSON()).Init(nil)
+	assert(t, "JSONFileProvider.Init: syntax error: 1:1: invalid character '}' looking for beginning of value", err.Error())
+}
+
+func TestUnmarshalRelaxedJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected any
+	}{
+		"strict JSON": {
+			input:    `{"a": [1, "x"], "b": null}`,
+			expected: map[string]any{"a": []any{1.0, "x"}, "b": nil},
+		},
+		"literals aren't quoted": {
+			input:    `{a: true, b: false, c: null, d: 1e3}`,
+			expected: map[string]any{"a": true, "b": false, "c": nil, "d": 1000.0},
+		},
+		"comments between key and colon": {
+			input:    "{a /* c */ : 1, // c\n}",
+			expected: map[string]any{"a": 1.0},
+		},
+		"comment after trailing comma": {
+			input:    "[1, /* c */ ]",
+			expected: []any{1.0},
+		},
+		"escaped quote in string": {
+			input:    `{"a": "x\" // y"}`,
+			expected: map[string]any{"a": `x" // y`},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got any
+			if err := unmarshalRelaxedJSON([]byte(test.input), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert(t, test.expected, got)
+		})
+	}
+}
+
+func TestUnmarshalRelaxedJSON_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"invalid character": {
+			input:    "{\n  a: 1,\n  b: ]\n}",
+			expected: "syntax error: 3:6: invalid character ']' looking for beginning of value",
+		},
+		"not closed comment": {
+			input:    "{\n /* a: 1 }",
+			expected: "syntax error: 2:2: comment is not closed",
+		},
+		"unexpected end": {
+			input:    "{\n  a: 1,\n",
+			expected: "syntax error: 3:1: unexpected end of JSON input",
+		},
+		"invalid last character": {
+			input:    "[1,\n}",
+			expected: "syntax error: 2:1: invalid character '}' after array element",
+		},
+		"unquoted value": {
+			input:    "{a: b}",
+			expected: "syntax error: 1:5: invalid character 'b' looking for beginning of value",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got any
+			err := unmarshalRelaxedJSON([]byte(test.input), &got)
+			assert(t, test.expected, err.Error())
+			assert(t, true, errors.Is(err, ErrSyntax))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package configuration
+
+import (
+	"context"
+	"time"
+)
+
+// Reloader is implemented by providers which can load their sources again after Init (e.g. SQLProvider).
+// Reload may be called concurrently with Provide, new values are used by the next Provide calls.
+type Reloader interface {
+	// Reload loads the source again and reports whether values have been changed.
+	Reload(ctx context.Context) (bool, error)
+}
+
+// Watch reloads the provider until ctx is done waiting interval between reloads and calls onChange
+// when values have been changed, e.g. to fill up the configuration again. Errors of Reload are passed
+// to onError (it may be nil) and the next reload is done after the interval as usual.
+// It blocks until ctx is done and returns its error.
+func Watch(ctx context.Context, r Reloader, interval time.Duration, onChange func(), onError func(error)) error {
+	for {
+		changed, err := r.Reload(ctx)
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err() // nolint:wrapcheck
+		case err != nil && onError != nil:
+			onError(err)
+		case err == nil && changed:
+			onChange()
+		}
+
+		if err := sleepCtx(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepCtx waits for the duration or returns the error of ctx if it's done first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() // nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
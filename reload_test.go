@@ -0,0 +1,65 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeReloader returns results one by one and cancels the context when they are over.
+type fakeReloader struct {
+	results []error // nil means a change, errNoChange means no changes
+	cancel  context.CancelFunc
+}
+
+var errNoChange = errors.New("no change")
+
+func (r *fakeReloader) Reload(_ context.Context) (bool, error) {
+	if len(r.results) == 0 {
+		r.cancel()
+		return false, nil
+	}
+
+	err := r.results[0]
+	r.results = r.results[1:]
+
+	if errors.Is(err, errNoChange) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errFailed := errors.New("connection refused")
+	r := &fakeReloader{results: []error{errNoChange, nil, errFailed, nil}, cancel: cancel}
+
+	var (
+		changes int
+		errs    []error
+	)
+	err := Watch(ctx, r, time.Millisecond, func() { changes++ }, func(err error) { errs = append(errs, err) })
+	assert(t, context.Canceled, err)
+	assert(t, 2, changes)
+	assert(t, []error{errFailed}, errs)
+}
+
+func TestWatch_WithoutOnError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := &fakeReloader{results: []error{errors.New("timeout"), nil}, cancel: cancel}
+
+	var changes int
+	err := Watch(ctx, r, time.Millisecond, func() { changes++ }, nil)
+	assert(t, context.Canceled, err)
+	assert(t, 1, changes)
+}
@@ -0,0 +1,145 @@
+package configuration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	SQLProviderName = `SQLProvider`
+	SQLProviderTag  = `db`
+)
+
+type SQLProviderOption func(*SQLProvider)
+
+// WithSQLArgs sets arguments of the query, e.g. the name of the application.
+func WithSQLArgs(args ...any) SQLProviderOption {
+	return func(sp *SQLProvider) {
+		sp.args = args
+	}
+}
+
+// WithSQLTimeout sets the timeout of the query (10s by default).
+func WithSQLTimeout(timeout time.Duration) SQLProviderOption {
+	return func(sp *SQLProvider) {
+		sp.timeout = timeout
+	}
+}
+
+// NewSQLProvider creates new provider which loads settings from the database during Init (and Reload).
+// The query must return two columns: the key and the value, e.g. `SELECT key, value FROM settings`.
+// Values are addressed by the key: `db:"max_connections"`, rows with NULL values are skipped.
+func NewSQLProvider(db *sql.DB, query string, opts ...SQLProviderOption) *SQLProvider {
+	sp := &SQLProvider{
+		db:      db,
+		query:   query,
+		timeout: defaultTimeout,
+	}
+
+	for _, f := range opts {
+		f(sp)
+	}
+
+	return sp
+}
+
+type SQLProvider struct {
+	db      *sql.DB
+	query   string
+	args    []any
+	timeout time.Duration
+	mu      sync.RWMutex
+	values  map[string]string
+}
+
+func (*SQLProvider) Name() string {
+	return SQLProviderName
+}
+
+func (*SQLProvider) Tag() string {
+	return SQLProviderTag
+}
+
+func (sp *SQLProvider) Init(_ any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sp.timeout)
+	defer cancel()
+
+	values, err := sp.load(ctx)
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w", SQLProviderName, err)
+	}
+
+	sp.mu.Lock()
+	sp.values = values
+	sp.mu.Unlock()
+
+	return nil
+}
+
+func (sp *SQLProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	key := field.Tag.Get(SQLProviderTag)
+	if len(key) == 0 {
+		// field doesn't have a proper tag
+		return fmt.Errorf("%s: key is empty", SQLProviderName)
+	}
+
+	sp.mu.RLock()
+	val, ok := sp.values[key]
+	sp.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%s: %w", SQLProviderName, ErrEmptyValue)
+	}
+
+	return SetField(field, v, val)
+}
+
+// Reload loads settings again and reports whether they have been changed, e.g. to be called by Watch periodically.
+func (sp *SQLProvider) Reload(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, sp.timeout)
+	defer cancel()
+
+	values, err := sp.load(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%s.Reload: %w", SQLProviderName, err)
+	}
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	changed := !maps.Equal(sp.values, values)
+	sp.values = values
+
+	return changed, nil
+}
+
+func (sp *SQLProvider) load(ctx context.Context) (map[string]string, error) {
+	rows, err := sp.db.QueryContext(ctx, sp.query, sp.args...)
+	if err != nil {
+		return nil, err // nolint:wrapcheck
+	}
+	defer rows.Close()
+
+	values := map[string]string{}
+	for rows.Next() {
+		var (
+			key string
+			val sql.NullString
+		)
+
+		if err := rows.Scan(&key, &val); err != nil {
+			return nil, err // nolint:wrapcheck
+		}
+
+		if val.Valid {
+			values[key] = val.String
+		}
+	}
+
+	return values, rows.Err() // nolint:wrapcheck
+}
@@ -0,0 +1,180 @@
+package configuration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSettingsDriver is a database/sql driver which answers every query with rows of the settings table.
+type fakeSettingsDriver struct {
+	mu   sync.Mutex
+	rows [][2]any
+	err  error
+	args []driver.NamedValue
+}
+
+func (d *fakeSettingsDriver) Open(string) (driver.Conn, error) {
+	return fakeSettingsConn{d: d}, nil
+}
+
+func (d *fakeSettingsDriver) set(key string, val any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i := range d.rows {
+		if d.rows[i][0] == key {
+			d.rows[i][1] = val
+			return
+		}
+	}
+	d.rows = append(d.rows, [2]any{key, val})
+}
+
+type fakeSettingsConn struct {
+	d *fakeSettingsDriver
+}
+
+func (c fakeSettingsConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c fakeSettingsConn) Close() error {
+	return nil
+}
+
+func (c fakeSettingsConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c fakeSettingsConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+
+	if c.d.err != nil {
+		return nil, c.d.err
+	}
+	c.d.args = args
+
+	return &fakeSettingsRows{rows: append([][2]any(nil), c.d.rows...)}, nil
+}
+
+type fakeSettingsRows struct {
+	rows [][2]any
+}
+
+func (r *fakeSettingsRows) Columns() []string {
+	return []string{"key", "value"}
+}
+
+func (r *fakeSettingsRows) Close() error {
+	return nil
+}
+
+func (r *fakeSettingsRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], dest[1] = r.rows[0][0], r.rows[0][1]
+	r.rows = r.rows[1:]
+
+	return nil
+}
+
+var (
+	_fakeSettings     = &fakeSettingsDriver{}
+	_fakeSettingsOnce sync.Once
+)
+
+func openFakeSettings(t *testing.T, rows [][2]any) (*sql.DB, *fakeSettingsDriver) {
+	t.Helper()
+
+	_fakeSettingsOnce.Do(func() {
+		sql.Register("fakesettings", _fakeSettings)
+	})
+
+	_fakeSettings.mu.Lock()
+	_fakeSettings.rows, _fakeSettings.err = rows, nil
+	_fakeSettings.mu.Unlock()
+
+	db, err := sql.Open("fakesettings", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db, _fakeSettings
+}
+
+func TestSQLProvider(t *testing.T) {
+	db, d := openFakeSettings(t, [][2]any{
+		{"max_connections", "100"},
+		{"timeout", "5s"},
+		{"feature_flags", "a;b"},
+		{"maintenance", nil},
+	})
+
+	type cfg struct {
+		MaxConnections int           `db:"max_connections"`
+		Timeout        time.Duration `db:"timeout"`
+		FeatureFlags   []string      `db:"feature_flags"`
+		Maintenance    bool          `db:"maintenance" default:"false"`
+	}
+
+	got, err := New[cfg](NewSQLProvider(db, "SELECT key, value FROM settings WHERE app = $1", WithSQLArgs("billing")), NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, &cfg{MaxConnections: 100, Timeout: 5 * time.Second, FeatureFlags: []string{"a", "b"}}, got)
+	assert(t, "billing", d.args[0].Value)
+
+	d.err = errors.New("relation \"settings\" does not exist")
+	_, err = New[cfg](NewSQLProvider(db, "SELECT key, value FROM settings"))
+	assert(t, "cannot init [SQLProvider] provider: SQLProvider.Init: relation \"settings\" does not exist", err.Error())
+}
+
+func TestSQLProvider_Watch(t *testing.T) {
+	db, d := openFakeSettings(t, [][2]any{{"timeout", "5s"}})
+
+	type cfg struct {
+		Timeout time.Duration `db:"timeout"`
+	}
+
+	p := NewSQLProvider(db, "SELECT key, value FROM settings")
+	if _, err := New[cfg](p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan *cfg)
+	go func() {
+		_ = Watch(ctx, p, 5*time.Millisecond, func() {
+			got, err := New[cfg](p)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			changes <- got
+		}, nil)
+	}()
+
+	time.Sleep(20 * time.Millisecond) // a few refreshes without changes
+	d.set("timeout", "10s")
+
+	select {
+	case got := <-changes:
+		assert(t, 10*time.Second, got.Timeout)
+	case <-time.After(time.Second):
+		t.Fatal("the change hasn't been noticed")
+	}
+
+	changed, err := p.Reload(context.Background())
+	assert(t, nil, err)
+	assert(t, false, changed)
+}
@@ -0,0 +1,140 @@
+package configuration
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Subcommand binds a configuration struct to the name of a subcommand (e.g. `serve`, `migrate`).
+type Subcommand interface {
+	// Name of the subcommand
+	Name() string
+	run(args []string) (any, []string, error)
+}
+
+// Command describes the subcommand selected by NewWithSubcommands.
+type Command struct {
+	// Name of the selected subcommand
+	Name string
+	// Config is a pointer to the populated struct of the subcommand, e.g. *ServeConfig
+	Config any
+	// Args are positional arguments left after the subcommand flags
+	Args []string
+}
+
+// NewSubcommand creates a subcommand which populates T using given providers.
+// Flag providers parse arguments following the subcommand name. If a flag provider uses flag.CommandLine,
+// it's replaced with a new flag.FlagSet to avoid collisions with global flags.
+func NewSubcommand[T any](name string, providers ...Provider) Subcommand {
+	return subcommand[T]{
+		name:      name,
+		providers: providers,
+	}
+}
+
+type subcommand[T any] struct {
+	name      string
+	providers []Provider
+}
+
+func (s subcommand[T]) Name() string {
+	return s.name
+}
+
+func (s subcommand[T]) run(args []string) (any, []string, error) {
+	providers := slices.Clone(s.providers)
+
+	var fs FlagSet
+	for i, p := range providers {
+		fp, ok := p.(flagProvider)
+		if !ok {
+			continue
+		}
+
+		if fp.flagSet == flag.CommandLine {
+			fp.flagSet = flag.NewFlagSet(s.name, flag.ExitOnError)
+		}
+		fp.args = args
+		providers[i] = fp
+		fs = fp.flagSet
+	}
+
+	cfg, err := New[T](providers...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rest := args
+	if fs != nil {
+		if rest, err = remainingArgs(fs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return cfg, rest, nil
+}
+
+// NewWithSubcommands populates the global options struct G using providers and dispatches
+// on the first positional argument: the matching subcommand populates its own struct
+// from the arguments which follow it.
+//
+//	global, cmd, err := NewWithSubcommands[Global](
+//		[]Subcommand{
+//			NewSubcommand[ServeConfig]("serve", NewFlagProvider(), NewDefaultProvider()),
+//			NewSubcommand[MigrateConfig]("migrate", NewFlagProvider()),
+//		},
+//		NewFlagProvider(),
+//	)
+//	switch cfg := cmd.Config.(type) {
+//	case *ServeConfig: // ...
+//	}
+func NewWithSubcommands[G any](subcommands []Subcommand, providers ...Provider) (*G, *Command, error) {
+	global, err := New[G](providers...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args := os.Args[1:]
+	for _, p := range providers {
+		if fp, ok := p.(flagProvider); ok {
+			if args, err = remainingArgs(fp.flagSet); err != nil {
+				return nil, nil, err
+			}
+			break
+		}
+	}
+
+	if len(args) == 0 {
+		return nil, nil, ErrNoSubcommand
+	}
+
+	for _, sc := range subcommands {
+		if sc.Name() != args[0] {
+			continue
+		}
+
+		cfg, rest, err := sc.run(args[1:])
+		if err != nil {
+			return nil, nil, fmt.Errorf("subcommand [%s]: %w", sc.Name(), err)
+		}
+
+		return global, &Command{
+			Name:   sc.Name(),
+			Config: cfg,
+			Args:   rest,
+		}, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: %s", ErrUnknownSubcommand, args[0])
+}
+
+func remainingArgs(fs FlagSet) ([]string, error) {
+	afs, ok := fs.(argsFlagSet)
+	if !ok {
+		return nil, fmt.Errorf("%w: FlagSet must implement Args() to dispatch subcommands", ErrInvalidInput)
+	}
+
+	return afs.Args(), nil
+}
@@ -0,0 +1,108 @@
+// nolint:paralleltest
+package configuration
+
+import (
+	"flag"
+	"testing"
+)
+
+type (
+	_globalCfg struct {
+		Verbose bool   `flag:"verbose|false"`
+		Env     string `default:"dev"`
+	}
+	_serveCfg struct {
+		Port int    `flag:"port|8080"`
+		Host string `default:"localhost"`
+	}
+	_migrateCfg struct {
+		Steps int `flag:"steps"`
+	}
+)
+
+func newTestSubcommands() []Subcommand {
+	return []Subcommand{
+		NewSubcommand[_serveCfg]("serve", NewFlagProvider(), NewDefaultProvider()),
+		NewSubcommand[_migrateCfg]("migrate",
+			NewFlagProvider(WithFlagSet(flag.NewFlagSet("migrate", flag.ContinueOnError))),
+		),
+	}
+}
+
+func TestNewWithSubcommands(t *testing.T) {
+	global, cmd, err := NewWithSubcommands[_globalCfg](
+		newTestSubcommands(),
+		NewFlagProvider(
+			WithFlagSet(flag.NewFlagSet("app", flag.ContinueOnError)),
+			WithArgs([]string{"-verbose=true", "serve", "-port", "9090", "extra"}),
+		),
+		NewDefaultProvider(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, &_globalCfg{Verbose: true, Env: "dev"}, global)
+	assert(t, "serve", cmd.Name)
+	assert(t, &_serveCfg{Port: 9090, Host: "localhost"}, cmd.Config)
+	assert(t, []string{"extra"}, cmd.Args)
+}
+
+func TestNewWithSubcommands_Migrate(t *testing.T) {
+	_, cmd, err := NewWithSubcommands[_globalCfg](
+		newTestSubcommands(),
+		NewFlagProvider(
+			WithFlagSet(flag.NewFlagSet("app", flag.ContinueOnError)),
+			WithArgs([]string{"migrate", "-steps=3"}),
+		),
+		NewDefaultProvider(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, "migrate", cmd.Name)
+	assert(t, &_migrateCfg{Steps: 3}, cmd.Config)
+	assert(t, []string{}, cmd.Args)
+}
+
+func TestNewWithSubcommands_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		args     []string
+		flagSet  FlagSet
+		expected string
+	}{
+		"no subcommand": {
+			args:     []string{"-verbose=true"},
+			flagSet:  flag.NewFlagSet("app", flag.ContinueOnError),
+			expected: "no subcommand",
+		},
+		"unknown subcommand": {
+			args:     []string{"deploy"},
+			flagSet:  flag.NewFlagSet("app", flag.ContinueOnError),
+			expected: "unknown subcommand: deploy",
+		},
+		"subcommand failed": {
+			args:     []string{"migrate"},
+			flagSet:  flag.NewFlagSet("app", flag.ContinueOnError),
+			expected: "subcommand [migrate]: field [Steps] with tags [flag:\"steps\"] hasn't been set",
+		},
+		"flag set without Args": {
+			args:     []string{"serve"},
+			flagSet:  &_flagSetMock{result: "true"},
+			expected: "invalid input: FlagSet must implement Args() to dispatch subcommands",
+		},
+	}
+
+	for name, test := range testCases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			_, _, err := NewWithSubcommands[_globalCfg](
+				newTestSubcommands(),
+				NewFlagProvider(WithFlagSet(test.flagSet), WithArgs(test.args)),
+				NewDefaultProvider(),
+			)
+			assert(t, test.expected, err.Error())
+		})
+	}
+}
@@ -0,0 +1,3 @@
+db {
+  host = "db.internal"
+}
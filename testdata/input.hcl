@@ -0,0 +1,40 @@
+# comment
+name    = "test_name_hcl"
+timeout = "101ms"
+ratio   = -1.5e2
+tags    = ["a", "b",
+  "c", // trailing comma is allowed
+]
+
+/* multi-line
+   comment */
+server {
+  host = "localhost"
+  port = 8080
+  tls  = true
+}
+
+service "web" {
+  port = 80
+  motd = <<-EOT
+    hello
+      world
+  EOT
+}
+
+service "api" {
+  port = 8081
+}
+
+rule {
+  name = "first"
+}
+
+rule {
+  name = "second"
+}
+
+limits = {
+  cpu    = 2
+  "mem": 512,
+}
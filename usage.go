@@ -0,0 +1,171 @@
+package configuration
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// DescriptionTag is used to describe a field in the generated usage.
+const DescriptionTag = `desc`
+
+const usageEmptyCell = "-"
+
+// PrintUsage writes a description of every field of T to w: its flag, env variable, JSON path,
+// default value, type and description (from the `desc` tag). Fields are grouped by nested structs.
+func PrintUsage[T any](w io.Writer) error {
+	return writeUsage(w, reflect.TypeOf(new(T)), false)
+}
+
+type usageGroup struct {
+	name string
+	rows [][]string
+}
+
+// writeUsage writes the usage of t, long flag names have `--` prefix if posix is set (as in POSIXFlagSet).
+func writeUsage(w io.Writer, t reflect.Type, posix bool) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return ErrNotAStruct
+	}
+
+	var groups []*usageGroup
+	collectUsage(t, "", posix, &groups)
+
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0) // nolint:mnd
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+
+		if len(g.name) > 0 {
+			fmt.Fprintf(tw, "%s:\n", g.name)
+		}
+
+		fmt.Fprintln(tw, "  FIELD\tTYPE\tFLAG\tENV\tJSON\tDEFAULT\tDESCRIPTION")
+		for _, row := range g.rows {
+			fmt.Fprintf(tw, "  %s\n", strings.Join(row, "\t"))
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("writeUsage: %w", err)
+	}
+
+	// cells are padded by tabwriter, so trailing spaces are trimmed when the description is empty
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return fmt.Errorf("writeUsage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func collectUsage(t reflect.Type, groupName string, posix bool, groups *[]*usageGroup) {
+	idx := len(*groups)
+	group := &usageGroup{name: groupName}
+	*groups = append(*groups, group)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if len(groupName) > 0 {
+			name = groupName + "." + field.Name
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			collectUsage(field.Type, name, posix, groups)
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+			collectUsage(field.Type.Elem(), name, posix, groups)
+			continue
+		}
+
+		group.rows = append(group.rows, usageRow(field, posix))
+	}
+
+	if len(group.rows) == 0 {
+		// nested structs only, there is nothing to show for the group itself
+		*groups = slices.Delete(*groups, idx, idx+1)
+	}
+}
+
+func usageRow(field reflect.StructField, posix bool) []string {
+	var (
+		flagNames  string
+		defaultVal = field.Tag.Get(DefaultProviderTag)
+		desc       = field.Tag.Get(DescriptionTag)
+	)
+
+	if fd, err := NewFlagProvider().getFlagData(field); err == nil {
+		names := make([]string, 0, len(fd.aliases)+1)
+		for _, n := range append([]string{fd.key}, fd.aliases...) {
+			if posix {
+				names = append(names, posixFlagName(n))
+			} else {
+				names = append(names, "-"+n)
+			}
+		}
+		flagNames = strings.Join(names, ", ")
+
+		if len(defaultVal) == 0 {
+			defaultVal = fd.defaultVal
+		}
+
+		if len(desc) == 0 {
+			desc = fd.usage
+		}
+	}
+
+	return []string{
+		field.Name,
+		field.Type.String(),
+		orEmptyCell(flagNames),
+		orEmptyCell(strings.ToUpper(field.Tag.Get(EnvProviderTag))),
+		orEmptyCell(field.Tag.Get(JSONFileProviderTag)),
+		orEmptyCell(defaultVal),
+		desc,
+	}
+}
+
+func orEmptyCell(s string) string {
+	if len(s) == 0 {
+		return usageEmptyCell
+	}
+
+	return s
+}
+
+// setUsage replaces the usage function of known flag sets with the generated one.
+func (fp flagProvider) setUsage(t reflect.Type) {
+	switch fs := fp.flagSet.(type) {
+	case *flag.FlagSet:
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "Usage of %s:\n", fs.Name())
+			_ = writeUsage(fs.Output(), t, false)
+		}
+
+	case *POSIXFlagSet:
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "Usage of %s:\n", fs.name)
+			_ = writeUsage(fs.Output(), t, true)
+		}
+	}
+}
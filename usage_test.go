@@ -0,0 +1,90 @@
+// nolint:paralleltest
+package configuration
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type usageTestCfg struct {
+	Name    string        `flag:"name,n|Alex|User name"`
+	Port    int           `env:"port"          default:"8080"       desc:"Port to listen"`
+	Timeout time.Duration `file_json:"timeout"`
+	DB      struct {
+		Host string `env:"DB_HOST" file_json:"db.host" desc:"Database host"`
+	}
+	Nested *struct {
+		Inner struct {
+			Tags []string `default:"a;b"`
+		}
+	}
+	hidden string // nolint:unused
+}
+
+const expectedUsage = `  FIELD    TYPE           FLAG       ENV   JSON     DEFAULT  DESCRIPTION
+  Name     string         -name, -n  -     -        Alex     User name
+  Port     int            -          PORT  -        8080     Port to listen
+  Timeout  time.Duration  -          -     timeout  -
+
+DB:
+  FIELD  TYPE    FLAG  ENV      JSON     DEFAULT  DESCRIPTION
+  Host   string  -     DB_HOST  db.host  -        Database host
+
+Nested.Inner:
+  FIELD  TYPE      FLAG  ENV  JSON  DEFAULT  DESCRIPTION
+  Tags   []string  -     -    -     a;b
+`
+
+func TestPrintUsage(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	if err := PrintUsage[usageTestCfg](out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert(t, expectedUsage, out.String())
+}
+
+func TestPrintUsage_NotAStruct(t *testing.T) {
+	t.Parallel()
+
+	err := PrintUsage[int](&bytes.Buffer{})
+	assert(t, ErrNotAStruct, err)
+}
+
+func TestFlagProvider_GeneratedUsage(t *testing.T) {
+	os.Args = []string{"smth", "-help"}
+
+	out := &bytes.Buffer{}
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(out)
+
+	err := NewFlagProvider(WithFlagSet(fs), WithGeneratedUsage()).Init(&usageTestCfg{})
+	assert(t, true, errors.Is(err, flag.ErrHelp))
+	assert(t, "Usage of app:\n"+expectedUsage, out.String())
+}
+
+func TestFlagProvider_GeneratedUsagePOSIX(t *testing.T) {
+	out := &bytes.Buffer{}
+	fs := NewPOSIXFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(out)
+
+	p := NewFlagProvider(WithFlagSet(fs), WithGeneratedUsage(), WithArgs([]string{"--help"}))
+	err := p.Init(&usageTestCfg{})
+	assert(t, true, errors.Is(err, flag.ErrHelp))
+
+	// long names are passed with `--` to the POSIX flag set
+	expected := `Usage of app:
+  FIELD    TYPE           FLAG        ENV   JSON     DEFAULT  DESCRIPTION
+  Name     string         --name, -n  -     -        Alex     User name
+  Port     int            -           PORT  -        8080     Port to listen
+  Timeout  time.Duration  -           -     timeout  -
+`
+	assert(t, true, strings.HasPrefix(out.String(), expected))
+}
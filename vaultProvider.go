@@ -0,0 +1,399 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	VaultProviderName = `VaultProvider`
+	VaultProviderTag  = `vault`
+
+	vaultKeySeparator = "#"
+)
+
+type VaultProviderOption func(*VaultProvider)
+
+// WithVaultToken sets the token which is used for authentication (VAULT_TOKEN env variable by default).
+func WithVaultToken(token string) VaultProviderOption {
+	return func(vp *VaultProvider) {
+		vp.token = token
+	}
+}
+
+// WithVaultAppRole authenticates by AppRole during Init, the token is received by login.
+func WithVaultAppRole(roleID, secretID string) VaultProviderOption {
+	return func(vp *VaultProvider) {
+		vp.roleID = roleID
+		vp.secretID = secretID
+	}
+}
+
+// WithVaultNamespace sets the namespace which is sent in `X-Vault-Namespace` header.
+func WithVaultNamespace(namespace string) VaultProviderOption {
+	return func(vp *VaultProvider) {
+		vp.namespace = namespace
+	}
+}
+
+// WithVaultHTTPClient sets the client which is used instead of http.DefaultClient.
+func WithVaultHTTPClient(client *http.Client) VaultProviderOption {
+	return func(vp *VaultProvider) {
+		vp.client = client
+	}
+}
+
+// WithVaultTimeout sets the timeout of requests in Init (10s by default).
+func WithVaultTimeout(timeout time.Duration) VaultProviderOption {
+	return func(vp *VaultProvider) {
+		vp.timeout = timeout
+	}
+}
+
+// WithVaultOnRenew sets the hook which is called after the token is renewed and secrets are read again by Reload.
+// It receives the new TTL of the token, e.g. to adjust the interval of reloads.
+func WithVaultOnRenew(fn func(ttl time.Duration)) VaultProviderOption {
+	return func(vp *VaultProvider) {
+		vp.onRenew = fn
+	}
+}
+
+// NewVaultProvider creates new provider which reads secrets from Vault KV v2 engine (`https://vault:8200`).
+// Values are addressed by `vault:"secret/data/db#password"` tag: the path of the secret and the key inside it.
+// Every secret is read once during Init no matter how many fields refer to it.
+func NewVaultProvider(addr string, opts ...VaultProviderOption) *VaultProvider {
+	vp := &VaultProvider{
+		addr:    strings.TrimRight(addr, "/"),
+		token:   os.Getenv("VAULT_TOKEN"),
+		client:  http.DefaultClient,
+		timeout: defaultTimeout,
+	}
+
+	for _, f := range opts {
+		f(vp)
+	}
+
+	return vp
+}
+
+type VaultProvider struct {
+	addr      string
+	token     string
+	roleID    string
+	secretID  string
+	namespace string
+	client    *http.Client
+	timeout   time.Duration
+	onRenew   func(ttl time.Duration)
+	mu        sync.RWMutex
+	ttl       time.Duration
+	secrets   map[string]map[string]any
+}
+
+type vaultAuthResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+type vaultSecretResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+func (*VaultProvider) Name() string {
+	return VaultProviderName
+}
+
+func (*VaultProvider) Tag() string {
+	return VaultProviderTag
+}
+
+func (vp *VaultProvider) Init(ptr any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), vp.timeout)
+	defer cancel()
+
+	if len(vp.roleID) > 0 {
+		if err := vp.login(ctx); err != nil {
+			return fmt.Errorf("%s.Init: %w", VaultProviderName, err)
+		}
+	}
+
+	secrets, err := vp.readAll(ctx, vaultPaths(reflect.TypeOf(ptr)))
+	if err != nil {
+		return fmt.Errorf("%s.Init: %w", VaultProviderName, err)
+	}
+
+	vp.mu.Lock()
+	vp.secrets = secrets
+	vp.mu.Unlock()
+
+	return nil
+}
+
+func (vp *VaultProvider) Provide(field reflect.StructField, v reflect.Value) error {
+	path, key, err := vaultKey(field.Tag.Get(VaultProviderTag))
+	if err != nil {
+		return err
+	}
+
+	val, err := vp.secretValue(path, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", VaultProviderName, err)
+	}
+
+	return SetField(field, v, valToString(val))
+}
+
+func (vp *VaultProvider) secretValue(path, key string) (any, error) {
+	vp.mu.RLock()
+	secret, ok := vp.secrets[path]
+	vp.mu.RUnlock()
+
+	if !ok {
+		// the field wasn't found during Init, e.g. it's a field of a subcommand
+		ctx, cancel := context.WithTimeout(context.Background(), vp.timeout)
+		defer cancel()
+
+		var err error
+		if secret, err = vp.read(ctx, path); err != nil {
+			// the same failure during Init isn't hidden by the next provider either
+			return nil, fmt.Errorf("%w: %w", ErrProviderFailed, err)
+		}
+
+		vp.mu.Lock()
+		if vp.secrets == nil {
+			vp.secrets = map[string]map[string]any{}
+		}
+		vp.secrets[path] = secret
+		vp.mu.Unlock()
+	}
+
+	val, ok := secret[key]
+	if !ok || val == nil {
+		return nil, ErrEmptyValue
+	}
+
+	return val, nil
+}
+
+// Reload renews the token (or logs in again with AppRole if it can't be renewed), reads all secrets again
+// and calls the hook of WithVaultOnRenew. It's meant to be called by Watch with the interval less than the TTL of the token.
+func (vp *VaultProvider) Reload(ctx context.Context) (bool, error) {
+	if err := vp.renewToken(ctx); err != nil {
+		if len(vp.roleID) == 0 {
+			return false, fmt.Errorf("%s.Reload: %w", VaultProviderName, err)
+		}
+
+		if err := vp.login(ctx); err != nil {
+			return false, fmt.Errorf("%s.Reload: %w", VaultProviderName, err)
+		}
+	}
+
+	vp.mu.RLock()
+	paths := make([]string, 0, len(vp.secrets))
+	for path := range vp.secrets {
+		paths = append(paths, path)
+	}
+	vp.mu.RUnlock()
+
+	secrets, err := vp.readAll(ctx, paths)
+	if err != nil {
+		return false, fmt.Errorf("%s.Reload: %w", VaultProviderName, err)
+	}
+
+	vp.mu.Lock()
+	changed := !reflect.DeepEqual(vp.secrets, secrets)
+	vp.secrets = secrets
+	vp.mu.Unlock()
+
+	if vp.onRenew != nil {
+		vp.onRenew(vp.TokenTTL())
+	}
+
+	return changed, nil
+}
+
+// TokenTTL returns the TTL of the token which was received by the last login or renewal.
+func (vp *VaultProvider) TokenTTL() time.Duration {
+	vp.mu.RLock()
+	defer vp.mu.RUnlock()
+
+	return vp.ttl
+}
+
+func (vp *VaultProvider) login(ctx context.Context) error {
+	body := map[string]string{"role_id": vp.roleID, "secret_id": vp.secretID}
+
+	var resp vaultAuthResponse
+	if _, err := vp.do(ctx, http.MethodPost, "auth/approle/login", body, &resp); err != nil {
+		return fmt.Errorf("approle login: %w", err)
+	}
+
+	vp.mu.Lock()
+	vp.token = resp.Auth.ClientToken
+	vp.ttl = time.Duration(resp.Auth.LeaseDuration) * time.Second
+	vp.mu.Unlock()
+
+	return nil
+}
+
+func (vp *VaultProvider) renewToken(ctx context.Context) error {
+	var resp vaultAuthResponse
+	if _, err := vp.do(ctx, http.MethodPost, "auth/token/renew-self", struct{}{}, &resp); err != nil {
+		return fmt.Errorf("token renewal: %w", err)
+	}
+
+	vp.mu.Lock()
+	vp.ttl = time.Duration(resp.Auth.LeaseDuration) * time.Second
+	vp.mu.Unlock()
+
+	return nil
+}
+
+// readAll reads secrets at paths, missing secrets are stored as empty ones.
+func (vp *VaultProvider) readAll(ctx context.Context, paths []string) (map[string]map[string]any, error) {
+	secrets := make(map[string]map[string]any, len(paths))
+
+	for _, path := range paths {
+		secret, err := vp.read(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		secrets[path] = secret
+	}
+
+	return secrets, nil
+}
+
+// read reads the secret at path, missing secrets are empty.
+func (vp *VaultProvider) read(ctx context.Context, path string) (map[string]any, error) {
+	var resp vaultSecretResponse
+
+	status, err := vp.do(ctx, http.MethodGet, path, nil, &resp)
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return resp.Data.Data, nil
+}
+
+func (vp *VaultProvider) do(ctx context.Context, method, path string, body, out any) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, err // nolint:wrapcheck
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, vp.addr+"/v1/"+strings.TrimPrefix(path, "/"), reqBody)
+	if err != nil {
+		return 0, err // nolint:wrapcheck
+	}
+
+	vp.mu.RLock()
+	token := vp.token
+	vp.mu.RUnlock()
+
+	if len(token) > 0 {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	if len(vp.namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", vp.namespace)
+	}
+
+	resp, err := vp.client.Do(req)
+	if err != nil {
+		return 0, err // nolint:wrapcheck
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&vaultErr)
+
+		return resp.StatusCode, fmt.Errorf("%w: %s %s", ErrUnexpectedStatus, resp.Status, strings.Join(vaultErr.Errors, "; "))
+	}
+
+	return resp.StatusCode, json.NewDecoder(resp.Body).Decode(out) // nolint:wrapcheck
+}
+
+// vaultKey splits `secret/data/db#password` into the path and the key.
+func vaultKey(tag string) (string, string, error) {
+	if len(tag) == 0 {
+		// field doesn't have a proper tag
+		return "", "", fmt.Errorf("%s: key is empty", VaultProviderName)
+	}
+
+	path, key, ok := strings.Cut(tag, vaultKeySeparator)
+	if !ok || len(path) == 0 || len(key) == 0 {
+		return "", "", fmt.Errorf("%s: wrong secret definition [%s]", VaultProviderName, tag)
+	}
+
+	return path, key, nil
+}
+
+// vaultPaths returns unique paths of secrets referred by fields of the struct and nested structs.
+func vaultPaths(t reflect.Type) []string {
+	var (
+		paths []string
+		seen  = map[string]struct{}{}
+		walk  func(t reflect.Type)
+	)
+
+	walk = func(t reflect.Type) {
+		if t == nil {
+			return
+		}
+
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return
+		}
+
+		for i := range t.NumField() {
+			field := t.Field(i)
+
+			if isStructType(field.Type) {
+				walk(field.Type)
+				continue
+			}
+
+			path, _, err := vaultKey(field.Tag.Get(VaultProviderTag))
+			if err != nil {
+				continue
+			}
+
+			if _, ok := seen[path]; !ok {
+				seen[path] = struct{}{}
+				paths = append(paths, path)
+			}
+		}
+	}
+	walk(t)
+
+	return paths
+}
@@ -0,0 +1,232 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeVault is a stand-in of Vault with KV v2 engine, token and AppRole auth.
+type fakeVault struct {
+	mu        sync.Mutex
+	tokens    map[string]bool // token -> renewable
+	secrets   map[string]map[string]any
+	reads     map[string]int
+	logins    int
+	renewals  int
+	namespace string
+}
+
+func newFakeVault() *fakeVault {
+	return &fakeVault{
+		tokens: map[string]bool{"root": true},
+		secrets: map[string]map[string]any{
+			"secret/data/db":  {"user": "admin", "password": "s3cr3t", "port": 5432},
+			"secret/data/api": {"keys": []any{"a", "b"}},
+		},
+		reads: map[string]int{},
+	}
+}
+
+func (fv *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fv.mu.Lock()
+	defer fv.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+
+	if path == "auth/approle/login" {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors": ["invalid role or secret ID"]}`))
+			return
+		}
+
+		fv.logins++
+		fv.tokens["approle"] = false
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "approle", "lease_duration": 60}}`))
+		return
+	}
+
+	renewable, ok := fv.tokens[r.Header.Get("X-Vault-Token")]
+	if !ok || r.Header.Get("X-Vault-Namespace") != fv.namespace {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+		return
+	}
+
+	if path == "auth/token/renew-self" {
+		if !renewable {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors": ["lease is not renewable"]}`))
+			return
+		}
+
+		fv.renewals++
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "root", "lease_duration": 3600}}`))
+		return
+	}
+
+	secret, ok := fv.secrets[path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": []}`))
+		return
+	}
+
+	fv.reads[path]++
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": secret}})
+}
+
+type _vaultCfg struct {
+	User     string `vault:"secret/data/db#user"`
+	Password string `vault:"secret/data/db#password"`
+	DB       struct {
+		Port int `vault:"secret/data/db#port"`
+	}
+	Keys  []string `vault:"secret/data/api#keys"`
+	Token string   `vault:"secret/data/missing#token" default:"none"`
+}
+
+func TestVaultProvider(t *testing.T) {
+	t.Parallel()
+
+	vault := newFakeVault()
+	srv := httptest.NewServer(vault)
+	defer srv.Close()
+
+	got, err := New[_vaultCfg](NewVaultProvider(srv.URL, WithVaultToken("root")), NewDefaultProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &_vaultCfg{User: "admin", Password: "s3cr3t", Keys: []string{"a", "b"}, Token: "none"}
+	expected.DB.Port = 5432
+	assert(t, expected, got)
+	assert(t, map[string]int{"secret/data/db": 1, "secret/data/api": 1}, vault.reads)
+
+	err = NewVaultProvider(srv.URL, WithVaultToken("wrong")).Init(&_vaultCfg{})
+	assert(t, "VaultProvider.Init: secret/data/db: unexpected status: 403 Forbidden permission denied", err.Error())
+}
+
+func TestVaultProvider_LazyReadFailed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(newFakeVault())
+	defer srv.Close()
+
+	p := NewVaultProvider(srv.URL, WithVaultToken("wrong"))
+	if err := p.Init(&struct{}{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the secret wasn't read during Init, e.g. it's a field of a subcommand
+	var val string
+	field := reflect.StructField{Name: "Val", Type: reflect.TypeOf(val), Tag: `vault:"secret/data/db#user" default:"none"`}
+
+	err := p.Provide(field, reflect.ValueOf(&val).Elem())
+	assert(t, true, errors.Is(err, ErrProviderFailed))
+	assert(t, "VaultProvider: provider failed: secret/data/db: unexpected status: 403 Forbidden permission denied", err.Error())
+}
+
+func TestVaultProvider_AppRole(t *testing.T) {
+	t.Parallel()
+
+	vault := newFakeVault()
+	vault.namespace = "team"
+	srv := httptest.NewServer(vault)
+	defer srv.Close()
+
+	var renewedTTL time.Duration
+	p := NewVaultProvider(srv.URL,
+		WithVaultAppRole("role", "secret"),
+		WithVaultNamespace("team"),
+		WithVaultOnRenew(func(ttl time.Duration) { renewedTTL = ttl }),
+	)
+
+	type cfg struct {
+		Password string `vault:"secret/data/db#password"`
+	}
+
+	got, err := New[cfg](p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "s3cr3t", got.Password)
+	assert(t, time.Minute, p.TokenTTL())
+
+	vault.mu.Lock()
+	vault.secrets["secret/data/db"]["password"] = "rotated"
+	vault.mu.Unlock()
+
+	// the AppRole token isn't renewable, so it logs in again
+	changed, err := p.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, true, changed)
+	assert(t, time.Minute, renewedTTL)
+	assert(t, 2, vault.logins)
+
+	got, err = New[cfg](p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, "rotated", got.Password)
+
+	err = NewVaultProvider(srv.URL, WithVaultAppRole("role", "wrong")).Init(&cfg{})
+	assert(t, "VaultProvider.Init: approle login: unexpected status: 400 Bad Request invalid role or secret ID", err.Error())
+}
+
+func TestVaultProvider_ReloadToken(t *testing.T) {
+	t.Parallel()
+
+	vault := newFakeVault()
+	srv := httptest.NewServer(vault)
+	defer srv.Close()
+
+	p := NewVaultProvider(srv.URL, WithVaultToken("root"))
+	if err := p.Init(&_vaultCfg{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changed, err := p.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert(t, false, changed)
+	assert(t, time.Hour, p.TokenTTL())
+	assert(t, 1, vault.renewals)
+	assert(t, 2, vault.reads["secret/data/db"])
+
+	delete(vault.tokens, "root")
+	_, err = p.Reload(context.Background())
+	assert(t, true, errors.Is(err, ErrUnexpectedStatus))
+}
+
+func TestVaultKey(t *testing.T) {
+	t.Parallel()
+
+	path, key, err := vaultKey("secret/data/db#password")
+	assert(t, nil, err)
+	assert(t, "secret/data/db", path)
+	assert(t, "password", key)
+
+	_, _, err = vaultKey("secret/data/db")
+	assert(t, "VaultProvider: wrong secret definition [secret/data/db]", err.Error())
+
+	_, _, err = vaultKey("")
+	assert(t, "VaultProvider: key is empty", err.Error())
+
+	assert(t, []string{"secret/data/db", "secret/data/api", "secret/data/missing"}, vaultPaths(reflect.TypeOf(&_vaultCfg{})))
+	assert(t, []string(nil), vaultPaths(nil))
+}